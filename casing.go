@@ -0,0 +1,94 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// splitWords breaks a name into its words. Words are separated by
+// underscores, dashes and spaces, and by changes in case: "HTTPStatusOK"
+// gives "HTTP", "Status", "OK". Digits belong to the preceding word.
+//
+// The acronyms, given by -acronyms, are kept as a word where they start a
+// word and are not followed by a lower case letter, so "HTTPSURL" gives
+// "HTTPS", "URL" and "IPv4Addr" gives "IPv4", "Addr".
+func splitWords(name string, acronyms []string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 || unicode.IsUpper(r) {
+			if n := acronymAt(runes, i, acronyms); n > 0 {
+				if start >= 0 {
+					words = append(words, string(runes[start:i]))
+				}
+				words = append(words, string(runes[i:i+n]))
+				start = -1
+				i += n - 1
+				continue
+			}
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			// A new word starts at "aB" or "1B", or at the last upper case
+			// letter of an acronym followed by a lower case letter, "ABc".
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// acronymAt returns the length of the longest acronym at runes[i:] which
+// ends the word, or 0 if there is none.
+func acronymAt(runes []rune, i int, acronyms []string) int {
+	longest := 0
+	for _, acronym := range acronyms {
+		a := []rune(acronym)
+		end := i + len(a)
+		if len(a) <= longest || end > len(runes) || !slices.Equal(runes[i:end], a) {
+			continue
+		}
+		if end == len(runes) || !unicode.IsLower(runes[end]) && !unicode.IsDigit(runes[end]) {
+			longest = len(a)
+		}
+	}
+	return longest
+}
+
+// screamingSnakeCase converts name to SCREAMING_SNAKE_CASE.
+func screamingSnakeCase(name string, acronyms []string) string {
+	words := splitWords(name, acronyms)
+	for i, w := range words {
+		words[i] = strings.ToUpper(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// snakeCase converts name to snake_case.
+func snakeCase(name string, acronyms []string) string {
+	return strings.ToLower(strings.Join(splitWords(name, acronyms), "_"))
+}
+
+// kebabCase converts name to kebab-case.
+func kebabCase(name string, acronyms []string) string {
+	return strings.ToLower(strings.Join(splitWords(name, acronyms), "-"))
+}
@@ -10,6 +10,8 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -81,11 +83,81 @@ func TestEndToEnd(t *testing.T) {
 			if name == "cgo.go" {
 				testenv.NeedsTool(t, "cgo")
 			}
-			stringerCompileAndRun(t, t.TempDir(), stringer, typeName(name), name)
+			stringerCompileAndRun(t, t.TempDir(), stringer, typeName(name), name, endToEndFlags[name]...)
 		})
 	}
 }
 
+// endToEndFlags holds the additional stringer flags for test programs
+// that check optionally generated methods.
+var endToEndFlags = map[string][]string{
+	"addprefix.go":   {"-trimprefix=Phase", "-addprefix=k8s.io/", "-addsuffix=.phase", "-text"},
+	"aliasvalue.go":  {"-parse"},
+	"alternates.go":  {"-linecomment", "-text"},
+	"big.go":         {"-validate"},
+	"binary.go":      {"-binary"},
+	"category.go":    {"-category"},
+	"clamp.go":       {"-clamp"},
+	"count.go":       {"-count=exported"},
+	"debugstring.go": {"-debugstring"},
+	"declorder.go":   {"-declorder", "-values", "-usage", "-iter"},
+	"default.go":     {"-lookup={}ByName"},
+	"deprecated.go":  {"-deprecated=message"},
+	"enummap.go":     {"-enummap"},
+	"errcode.go":     {"-error=is"},
+	"fingerprint.go": {"-fingerprint"},
+	"flagiter.go":    {"-flags", "-iter"},
+	"flaglist.go":    {"-flags", "-values", "-options", "-enums", "-templatefuncs"},
+	"flagjson.go":    {"-flags", "-json"},
+	"flagparse.go":   {"-flags", "-parse", "-text"},
+	"flags.go":       {"-flags"},
+	"flagvalue.go":   {"-flagvalue"},
+	"formatter.go":   {"-formatter"},
+	"gostring.go":    {"-gostring", "-formatter"},
+	"gob.go":         {"-gob=register"},
+	"identifiers.go": {"-trimprefix=Status", "-linecomment", "-lookup={}ByName"},
+	"ignoresep.go":   {"-ignoresep", "-lookup={}ByName"},
+	"isvalid.go":     {"-isvalid"},
+	"iter.go":        {"-iter=all2"},
+	"jsonnum.go":     {"-json=number", "-checknumbers"},
+	"listing.go":     {"-values"},
+	"lookupbytes.go": {"-lookupbytes"},
+	"lookupsig.go":   {"-lookup=*Registry.{}ByName([]byte) error"},
+	"meta.go":        {"-meta"},
+	"minmax.go":      {"-minmax"},
+	"next.go":        {"-next=wrap"},
+	"options.go":     {"-options", "-linecomment"},
+	"ordinal.go":     {"-ordinal"},
+	"parse.go":       {"-parse=must"},
+	"parseany.go":    {"-parsenumbers=any"},
+	"parsenum.go":    {"-parsenumbers"},
+	"perfect.go":     {"-lookup-strategy=perfect", "-lookup={}ByName"},
+	"predicates.go":  {"-predicates", "-trimprefix=Status"},
+	"random.go":      {"-random"},
+	"registered.go":  {"-enums"},
+	"registry.go":    {"-registry"},
+	"rename.go":      {"-trimprefix=Status", "-linecomment"},
+	"rune.go":        {"-rune", "-parse"},
+	"set.go":         {"-set"},
+	"setwide.go":     {"-set"},
+	"skip.go":        {"-parse"},
+	"slice.go":       {"-slice"},
+	"slog.go":        {"-slog"},
+	"spelling.go":    {"-json", "-text"},
+	"sqlnull.go":     {"-sqlnull", "-json"},
+	"sql.go":         {"-sql"},
+	"strconst.go":    {"-parse", "-values", "-isvalid", "-text", "-json", "-linecomment"},
+	"template.go":    {"-templatefuncs"},
+	"text.go":        {"-text"},
+	"trimsuffix.go":  {"-trimsuffix=State"},
+	"unknownnum.go":  {"-unknown=number", "-text", "-json"},
+	"unknownzero.go": {"-unknown=zero", "-text", "-json"},
+	"usage.go":       {"-usage"},
+	"validate.go":    {"-validate", "-parse"},
+	"wirename.go":    {"-trimprefix=Status", "-linecomment", "-wirename", "-text"},
+	"xml.go":         {"-xml"},
+}
+
 // a type name for stringer. use the last component of the file name with the .go
 func typeName(fname string) string {
 	// file names are known to be ascii and end .go
@@ -186,6 +258,274 @@ func TestConstValueChange(t *testing.T) {
 	}
 }
 
+// TestConstRemoved verifies that removing a constant without regenerating
+// breaks the build, even if no other constant changes its value.
+func TestConstRemoved(t *testing.T) {
+	t.Logf("Note: the following messages should indicate an undefined constant\n")
+	checkStale(t, "day.go", "vary_removed_day.go")
+}
+
+// TestConstAdded verifies that adding a constant without regenerating
+// breaks the build for a type counting its values, even if no other
+// constant changes its value.
+func TestConstAdded(t *testing.T) {
+	t.Logf("Note: the following messages should indicate an out-of-bounds compiler error\n")
+	checkStale(t, "vary_counted_day.go", "vary_added_day.go")
+}
+
+// checkStale generates the String method of Day for the testdata file
+// from and verifies that it no longer builds after replacing the source
+// with the testdata file to.
+func checkStale(t *testing.T, from, to string) {
+	testenv.NeedsTool(t, "go")
+
+	stringer := stringerPath(t)
+	dir := t.TempDir()
+	source := filepath.Join(dir, "day.go")
+	err := copy(source, filepath.Join("testdata", from))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stringSource := filepath.Join(dir, "day_string.go")
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = runInDir(t, dir, stringer, "-type", "Day", "-output", stringSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Run the binary in the temporary directory as a sanity check.
+	err = run(t, "go", "run", stringSource, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = copy(source, filepath.Join("testdata", to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = run(t, "go", "build", stringSource, source)
+	if err == nil {
+		t.Fatal("unexpected compiler success")
+	}
+}
+
+// TestCompatCheck verifies that -compat-check rejects a regeneration
+// removing a name recorded by -lock.
+func TestCompatCheck(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	stringer := stringerPath(t)
+	dir := t.TempDir()
+	source := filepath.Join(dir, "day.go")
+	err := copy(source, filepath.Join("testdata", "day.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = runInDir(t, dir, stringer, "-type", "Day", "-lock", "-compat-check")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, lockName)); err != nil {
+		t.Fatal(err)
+	}
+	err = copy(source, filepath.Join("testdata", "vary_removed_day.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Note: the following messages should indicate a removed name\n")
+	err = runInDir(t, dir, stringer, "-type", "Day", "-lock", "-compat-check")
+	if err == nil {
+		t.Fatal("unexpected success removing a locked name")
+	}
+}
+
+func TestI18n(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	stringer := stringerPath(t)
+	dir := t.TempDir()
+	err := copy(filepath.Join(dir, "day.go"), filepath.Join("testdata", "day.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "locales"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	catalog := filepath.Join(dir, "locales", "de.json")
+	if err := os.WriteFile(catalog, []byte(`{"Day": {"Monday": "Montag"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = runInDir(t, dir, stringer, "-type", "Day", "-i18n=locales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "day_string.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(got, []byte(`Monday: "Montag"`)) {
+		t.Errorf("catalog not embedded in day_string.go:\n%s", got)
+	}
+	if err := os.WriteFile(catalog, []byte(`{"Day": {"Moonday": "Montag"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Note: the following messages should indicate an unknown name\n")
+	err = runInDir(t, dir, stringer, "-type", "Day", "-i18n=locales")
+	if err == nil {
+		t.Fatal("unexpected success translating an unknown name")
+	}
+}
+
+func TestFromPkg(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	stringer := stringerPath(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module test\n",
+		"color/color.go": `package color
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	blue
+)
+`,
+		"main.go": `package main
+
+import "test/color"
+
+func main() {
+	if s := Color(color.Green).String(); s != "Green" {
+		panic(s)
+	}
+	if s := Color(2).String(); s != "Color(2)" {
+		panic(s)
+	}
+}
+`,
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Logf("Note: the following messages should indicate that blue is skipped\n")
+	err := runInDir(t, dir, stringer, "-type", "Color", "-frompkg", "test/color")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = runInDir(t, dir, "go", "run", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRegistry verifies that separate runs with -registry in a package
+// share LookupEnum.
+func TestRegistry(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	stringer := stringerPath(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module test\n",
+		"main.go": `package main
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+)
+
+type Shape int
+
+const (
+	Circle Shape = iota
+	Square
+)
+
+func main() {
+	if v, ok := LookupEnum("Color", "Green"); v != Green || !ok {
+		panic("Color")
+	}
+	if v, ok := LookupEnum("Shape", "Square"); v != Square || !ok {
+		panic("Shape")
+	}
+}
+`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, typeName := range []string{"Color", "Shape"} {
+		if err := runInDir(t, dir, stringer, "-type", typeName, "-registry"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := runInDir(t, dir, "go", "run", "."); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestGenerate verifies that generate loads a directory of another module
+// from that module and writes the generated file into it.
+func TestGenerate(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	dir := t.TempDir()
+	if err := copy(filepath.Join(dir, "day.go"), filepath.Join("testdata", "day.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config{args: []string{dir}, types: []string{"Day"}}
+	if err := generate(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "day_string.go")); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGenerateCancel verifies that generate stops with the error of a
+// cancelled context, without writing any file.
+func TestGenerateCancel(t *testing.T) {
+	dir := t.TempDir()
+	if err := copy(filepath.Join(dir, "day.go"), filepath.Join("testdata", "day.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := config{args: []string{dir}, types: []string{"Day"}}
+	err := generate(ctx, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "day_string.go")); !os.IsNotExist(err) {
+		t.Errorf("output written after cancellation: %v", err)
+	}
+}
+
 var testfileSrcs = map[string]string{
 	"go.mod": "module foo",
 
@@ -327,7 +667,8 @@ func stringerPath(t *testing.T) string {
 
 // stringerCompileAndRun runs stringer for the named file and compiles and
 // runs the target binary in directory dir. That binary will panic if the String method is incorrect.
-func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName string) {
+// Additional flags for stringer may be passed in flags.
+func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName string, flags ...string) {
 	t.Logf("run: %s %s\n", fileName, typeName)
 	source := filepath.Join(dir, path.Base(fileName))
 	err := copy(source, filepath.Join("testdata", fileName))
@@ -336,12 +677,19 @@ func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName strin
 	}
 	stringSource := filepath.Join(dir, typeName+"_string.go")
 	// Run stringer in temporary directory.
-	err = run(t, stringer, "-type", typeName, "-output", stringSource, source)
+	args := append([]string{"-type", typeName, "-output", stringSource}, flags...)
+	err = run(t, stringer, append(args, source)...)
 	if err != nil {
 		t.Fatal(err)
 	}
-	// Run the binary in the temporary directory.
-	err = run(t, "go", "run", stringSource, source)
+	// Run the binary in the temporary directory, including the
+	// registries written by -enums and -registry.
+	files := []string{stringSource, source}
+	enums := filepath.Join(dir, "enums.go")
+	if _, err := os.Stat(enums); err == nil {
+		files = append(files, enums)
+	}
+	err = run(t, "go", append([]string{"run"}, files...)...)
 	if err != nil {
 		t.Fatal(err)
 	}
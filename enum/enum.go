@@ -0,0 +1,77 @@
+// Package enum is the runtime companion of morestringer. Types generated
+// with -runtime register themselves here, so that libraries can handle
+// any generated type generically, knowing only its reflect.Type.
+package enum
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Enum is implemented by all types generated by morestringer.
+type Enum interface {
+	String() string
+}
+
+// Parser returns the value of an enum type with the given name.
+type Parser interface {
+	Parse(name string) (Enum, error)
+}
+
+// Type describes a registered enum type. It implements Parser.
+type Type struct {
+	typ    reflect.Type
+	values []Enum
+	parse  func(name string) (Enum, error)
+}
+
+// Type returns the reflect.Type of the enum type.
+func (t *Type) Type() reflect.Type {
+	return t.typ
+}
+
+// Values returns all values of the type in ascending order.
+func (t *Type) Values() []Enum {
+	return append([]Enum(nil), t.values...)
+}
+
+// Names returns the names of the values, in the order of Values.
+func (t *Type) Names() []string {
+	names := make([]string, len(t.values))
+	for i, v := range t.values {
+		names[i] = v.String()
+	}
+	return names
+}
+
+// Parse returns the value with the given name.
+func (t *Type) Parse(name string) (Enum, error) {
+	return t.parse(name)
+}
+
+var types sync.Map // reflect.Type to *Type
+
+// Register registers the enum type typ with its values and parse
+// function. It is called by generated code during initialization.
+func Register(typ reflect.Type, values []Enum, parse func(name string) (Enum, error)) {
+	types.Store(typ, &Type{typ: typ, values: values, parse: parse})
+}
+
+// Of returns the registered enum type typ.
+func Of(typ reflect.Type) (*Type, bool) {
+	t, ok := types.Load(typ)
+	if !ok {
+		return nil, false
+	}
+	return t.(*Type), true
+}
+
+// All returns all registered enum types, in no particular order.
+func All() []*Type {
+	var all []*Type
+	types.Range(func(_, t any) bool {
+		all = append(all, t.(*Type))
+		return true
+	})
+	return all
+}
@@ -0,0 +1,66 @@
+package enum_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/friedelschoen/morestringer/enum"
+)
+
+type color int
+
+const (
+	red color = iota
+	green
+)
+
+func (c color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+func parseColor(name string) (color, error) {
+	switch name {
+	case "red":
+		return red, nil
+	case "green":
+		return green, nil
+	}
+	return 0, errors.New("invalid color")
+}
+
+func init() {
+	enum.Register(reflect.TypeFor[color](), []enum.Enum{red, green}, func(name string) (enum.Enum, error) {
+		return parseColor(name)
+	})
+}
+
+func TestOf(t *testing.T) {
+	typ, ok := enum.Of(reflect.TypeFor[color]())
+	if !ok {
+		t.Fatal("color is not registered")
+	}
+	if typ.Type() != reflect.TypeFor[color]() {
+		t.Errorf("Type() = %v", typ.Type())
+	}
+	if got := typ.Values(); !slices.Equal(got, []enum.Enum{red, green}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if got := typ.Names(); !slices.Equal(got, []string{"red", "green"}) {
+		t.Errorf("Names() = %q", got)
+	}
+	var p enum.Parser = typ
+	if v, err := p.Parse("green"); v != green || err != nil {
+		t.Errorf("Parse(green) = %v, %v", v, err)
+	}
+	if _, err := p.Parse("blue"); err == nil {
+		t.Error("Parse(blue) succeeded")
+	}
+	if _, ok := enum.Of(reflect.TypeFor[int]()); ok {
+		t.Error("int is registered")
+	}
+	if all := enum.All(); len(all) != 1 || all[0] != typ {
+		t.Errorf("All() = %v", all)
+	}
+}
@@ -3,13 +3,21 @@ package main
 import (
 	"bytes"
 	"cmp"
+	"context"
 	"fmt"
+	"go/constant"
 	"go/format"
+	"go/token"
+	"hash/fnv"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // usize returns the number of bits of the smallest unsigned integer
@@ -27,6 +35,22 @@ func usize(n int) int {
 	}
 }
 
+// vsize returns the number of bits of the smallest integer type that will
+// hold all values between lo and hi, which are the smallest and largest
+// value in a type.
+func vsize(lo, hi Value) int {
+	for _, n := range []int{8, 16, 32} {
+		if lo.signed {
+			if int64(lo.value) >= -1<<(n-1) && int64(hi.value) < 1<<(n-1) {
+				return n
+			}
+		} else if hi.value < 1<<n {
+			return n
+		}
+	}
+	return 64
+}
+
 // splitIntoRuns breaks the values into runs of contiguous sequences.
 // For example, given 1,2,3,5,6,7 it returns {1,2,3},{5,6,7}.
 // The input slice is known to be non-empty.
@@ -77,26 +101,127 @@ func fnv1a32(s string) uint32 {
 // Generator holds the state of the analysis. Primarily used to buffer
 // the output for format.Source.
 type Generator struct {
-	buf bytes.Buffer // Accumulated output.
+	buf      bytes.Buffer // Accumulated output.
+	pkgName  string       // Name of the package being generated.
+	intTypes bool         // Whether any of the types has integer constants, for the imports.
 
-	lookup string
-	json   bool
+	lookup         string
+	lookupWrapper  lookupSig // Custom shaped lookup calling the generated one.
+	lookupBytes    bool
+	lookupStrategy string // "hash", "perfect", "binary", "map" or "auto"; "" is "auto" with the default thresholds
+	hashMax        int    // Used by "auto", zero disables the hash-switch.
+	binaryMax      int    // Used by "auto", zero disables the binary search.
+	fold           bool
+	ignoreSep      bool
+	parse          string // "error" or "must"
+	parseNumbers   string // "defined" or "any"
+	slice          bool
+	values         bool
+	options        bool
+	declOrder      bool // List values in the order of their declaration.
+	usage          bool
+	templateFuncs  bool
+	flags          bool               // Print values as combinations of flags.
+	flagSep        string             // Separator of the names of flags.
+	flagUnknown    string             // Format of bits without a name: "hex", "decimal" or "type".
+	lock           bool               // Record the names and values in enum.lock.
+	compatCheck    bool               // Fail if names recorded in enum.lock changed.
+	catalogs       map[string]catalog // Translated names by locale, for -i18n.
+	message        bool               // Keys and messages for golang.org/x/text/message.
+	label          bool               // Names by language given by label directives.
+	acronyms       []string           // Words kept intact by the case transforms.
+	iter           string             // "all" or "all2"
+	next           string             // "clamp" or "wrap"
+	ordinal        bool
+	deprecated     string // "is" or "message"
+	category       bool
+	meta           bool
+	random         bool
+	set            bool
+	enumMap        bool
+	validate       bool
+	clamp          bool
+	predicates     bool
+	enums          bool
+	runtime        bool
+	wireName       string // "snake", "kebab" or "screaming"
+	debugString    bool
+	fingerprint    bool
+	count          string // "unexported" or "exported"
+	minMax         bool
+	isValid        bool
+	registry       bool
+	json           string // "name" or "number" when set.
+	text           bool
+	sql            bool
+	sqlnull        bool
+	yaml           bool
+	xml            bool
+	bson           string // "name" or "number" when set.
+	cbor           bool
+	msgpack        bool
+	binary         bool
+	gob            string // "methods" or "register" when set.
+	jsonv2         string // "name" or "number" when set.
+	graphql        bool
+	slog           bool
+	zap            bool
+	formatter      bool
+	gostring       bool
+	flagValue      bool
+	cobra          bool
+	kong           bool
+	mapstructure   bool
+	error          string // "methods" or "is"
+	validator      bool
+	prometheus     bool
+	proto          string                  // Protobuf enum as "pkg.Name".
+	protoPath      string                  // Import path of the protobuf enum.
+	protoConsts    map[uint64]string       // Names of the protobuf constants by value.
+	sentinels      map[string][]sentinel   // Constants counting the values of the types, checked by buildCheck.
+	ranges         map[string][]valueRange // Ranges of each type by the type name.
+	from           *Package                // Package declaring the types, given by -frompkg.
+
+	checkNumbers bool   // Reject unmarshaled numbers which are not a defined value.
+	unknown      string // Handling of unknown names: "error", "zero" or "number".
 }
 
 func (g *Generator) Printf(format string, args ...any) {
 	fmt.Fprintf(&g.buf, format, args...)
 }
 
-func (g *Generator) genPackage(pkg *Package, types []string, dir, output string) []string {
-	g.prologue(pkg.name)
+func (g *Generator) genPackage(ctx context.Context, pkg *Package, types []string, dir, output string) ([]string, error) {
+	g.pkgName = pkg.name
 
 	// Run generate for types that can be found. Keep the rest for the remainingTypes iteration.
 	var foundTypes, remainingTypes []string
 
-	typeValues := pkg.findValues(types...)
+	// Types of another package are generated for local copies of them.
+	source := pkg
+	if g.from != nil {
+		source = g.from
+	}
+	typeValues := source.findValues(types...)
+	g.ranges = source.findRanges(types...)
+	// The constants of another package are declared again without their
+	// sentinels, which are unexported by convention.
+	g.sentinels = nil
+	if g.from == nil {
+		g.sentinels = source.sentinels
+	}
+	if g.from != nil {
+		for typeName, values := range typeValues {
+			typeValues[typeName] = slices.DeleteFunc(values, func(v Value) bool {
+				if !token.IsExported(v.original) {
+					log.Printf("warning: %s of %s.%s is not exported and skipped", v.original, g.from.name, typeName)
+					return true
+				}
+				return false
+			})
+		}
+	}
 	for typeName, values := range typeValues {
 		if len(values) > 0 {
-			g.genType(typeName, values)
 			foundTypes = append(foundTypes, typeName)
 		} else {
 			remainingTypes = append(remainingTypes, typeName)
@@ -105,12 +230,30 @@ func (g *Generator) genPackage(pkg *Package, types []string, dir, output string)
 
 	if len(foundTypes) == 0 {
 		// This package didn't have any of the relevant types, skip writing a file.
-		return types
+		return types, nil
+	}
+	// The protobuf enum is imported by the package of the types, not
+	// necessarily by the other variants of it.
+	if g.proto != "" {
+		var err error
+		g.protoPath, g.protoConsts, err = pkg.protoEnum(g.proto)
+		if err != nil {
+			return nil, err
+		}
+	}
+	g.intTypes = slices.ContainsFunc(foundTypes, func(typeName string) bool {
+		return !typeValues[typeName][0].isString
+	})
+	g.prologue(pkg.name)
+	for _, typeName := range foundTypes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		g.genType(typeName, typeValues[typeName])
 	}
 	if len(remainingTypes) > 0 && output != "" {
-		log.Fatalf("cannot write to single file (-output=%q) when matching types are found in multiple packages", output)
+		return nil, fmt.Errorf("cannot write to single file (-output=%q) when matching types are found in multiple packages", output)
 	}
-	types = remainingTypes
 
 	// Format the output.
 	src := g.format()
@@ -123,11 +266,99 @@ func (g *Generator) genPackage(pkg *Package, types []string, dir, output string)
 		// and the separate package of tests (package foo_test).
 		output = filepath.Join(dir, baseName(pkg, foundTypes[0]))
 	}
-	err := os.WriteFile(output, src, 0o644)
+	// Nothing is written once cancelled, so that no output is left behind
+	// half done.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if g.compatCheck || g.lock {
+		err := g.updateLock(typeValues, foundTypes, filepath.Join(filepath.Dir(output), lockName))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		return nil, fmt.Errorf("writing output: %s", err)
+	}
+	if g.enums || g.registry {
+		if err := g.writeEnums(pkg, filepath.Dir(output)); err != nil {
+			return nil, err
+		}
+	}
+	return remainingTypes, nil
+}
+
+// updateLock checks the found types against the lock file with
+// -compat-check, before anything is written, and records them in it with
+// -lock. Types generated by other runs are kept.
+func (g *Generator) updateLock(typeValues map[string][]Value, foundTypes []string, path string) error {
+	lock, err := readLock(path)
+	if err != nil {
+		return fmt.Errorf("reading lock: %s", err)
+	}
+	for _, typeName := range foundTypes {
+		values := lockValues(typeValues[typeName])
+		if g.compatCheck {
+			if err := checkCompat(typeName, lock[typeName], values); err != nil {
+				return fmt.Errorf("incompatible with %s: %s", lockName, err)
+			}
+		}
+		lock[typeName] = values
+	}
+	if g.lock {
+		if err := writeLock(path, lock); err != nil {
+			return fmt.Errorf("writing lock: %s", err)
+		}
+	}
+	return nil
+}
+
+// writeEnums writes the file declaring the registries of enum types of
+// -enums and -registry, which the generated files fill. Its content does not
+// depend on the types or options, so that separate runs for the types of a
+// package can share it.
+func (g *Generator) writeEnums(pkg *Package, dir string) error {
+	name := "enums.go"
+	if pkg.hasTestFiles {
+		name = "enums_test.go"
+	}
+	g.buf.Reset()
+	g.buildEnumsFile(pkg.name)
+	err := os.WriteFile(filepath.Join(dir, name), g.format(), 0o644)
 	if err != nil {
-		log.Fatalf("writing output: %s", err)
+		return fmt.Errorf("writing enums: %s", err)
 	}
-	return types
+	return nil
+}
+
+func (g *Generator) buildEnumsFile(pkgname string) {
+	g.Printf("// Code generated by morestringer; DO NOT EDIT.\n")
+	g.Printf("\n")
+	g.Printf("package %s\n", pkgname)
+	g.Printf("\n")
+	g.Printf("// EnumType describes an enum type generated by morestringer.\n")
+	g.Printf("type EnumType struct {\n")
+	g.Printf("Name string // The name of the type.\n")
+	g.Printf("Values []any // All values in ascending order.\n")
+	g.Printf("Names []string // The names of Values.\n")
+	g.Printf("Parse func(name string) (any, error) // Returns the value with the given name.\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// EnumTypes holds the enum types of this package by their name.\n")
+	g.Printf("var EnumTypes = map[string]EnumType{}\n")
+	g.Printf("\n")
+	g.Printf("// _enumLookups holds the lookups of the enum types of this package by their name.\n")
+	g.Printf("var _enumLookups = map[string]func(name string) (any, bool){}\n")
+	g.Printf("\n")
+	g.Printf("// LookupEnum returns the value named valueName of the enum type named typeName,\n")
+	g.Printf("// for resolving values when the type is only known by its name.\n")
+	g.Printf("func LookupEnum(typeName, valueName string) (any, bool) {\n")
+	g.Printf("lookup, ok := _enumLookups[typeName]\n")
+	g.Printf("if !ok {\n")
+	g.Printf("return nil, false\n")
+	g.Printf("}\n")
+	g.Printf("return lookup(valueName)\n")
+	g.Printf("}\n")
 }
 
 func (g *Generator) prologue(pkgname string) {
@@ -137,11 +368,115 @@ func (g *Generator) prologue(pkgname string) {
 	g.Printf("package %s", pkgname)
 	g.Printf("\n")
 	g.Printf("import (\n")
-	if g.json {
-		g.Printf("\"encoding/json\"\n") // Used by all methods.
-		g.Printf("\"reflect\"\n")       // Used by all methods.
+	if g.parse != "" {
+		g.Printf("\"errors\"\n")
+	}
+	if g.json != "" || g.sqlnull {
+		g.Printf("\"encoding/json\"\n")
+	}
+	if g.json != "" && g.intTypes {
+		// Only the integer types decode numbers exactly.
+		g.Printf("\"bytes\"\n")
+	}
+	if g.json != "" || g.mapstructure || g.validator || g.random || g.runtime {
+		g.Printf("\"reflect\"\n")
+	}
+	if g.random {
+		g.Printf("\"math/rand\"\n")
+	}
+	if g.jsonv2 != "" {
+		g.Printf("\"encoding/json/jsontext\"\n")
+	}
+	if g.sql {
+		g.Printf("\"database/sql/driver\"\n")
+	}
+	if g.gob == "register" {
+		g.Printf("\"encoding/gob\"\n")
+	}
+	if g.xml {
+		g.Printf("\"encoding/xml\"\n")
+	}
+	if g.graphql {
+		g.Printf("\"io\"\n")
+	}
+	if g.iter != "" {
+		g.Printf("\"iter\"\n")
+	}
+	if g.slog {
+		g.Printf("\"log/slog\"\n")
+	}
+	if g.templateFuncs {
+		g.Printf("\"text/template\"\n")
+	}
+	if g.lookupWrapper.result == "error" || g.parse != "" || (g.text && g.unknown != "zero") || g.sql || g.yaml || g.xml || g.bson != "" || g.cbor || g.binary || g.gob != "" || g.jsonv2 != "" || g.graphql || g.formatter || g.flagValue || g.mapstructure {
+		g.Printf("\"fmt\"\n")
 	}
 	g.Printf("\"strconv\"\n") // Used by all methods.
+	if g.set {
+		g.Printf("\"math/bits\"\n")
+	}
+	if g.parse != "" || g.cobra || g.canonicalLookup() || g.set || (g.flags && (g.lookup != "" || g.needLookup())) || g.catalogs != nil || g.message {
+		g.Printf("\"strings\"\n")
+	}
+	if g.canonicalLookup() {
+		g.Printf("\"unicode\"\n")
+	}
+
+	// Third-party packages go into a separate group.
+	var external []string
+	if g.cbor {
+		external = append(external, "github.com/fxamacker/cbor/v2")
+	}
+	if g.runtime {
+		external = append(external, "github.com/friedelschoen/morestringer/enum")
+	}
+	if g.cobra {
+		external = append(external, "github.com/spf13/cobra")
+	}
+	if g.kong {
+		external = append(external, "github.com/alecthomas/kong")
+	}
+	if g.mapstructure {
+		external = append(external, "github.com/go-viper/mapstructure/v2")
+	}
+	if g.validator {
+		external = append(external, "github.com/go-playground/validator/v10")
+	}
+	if g.msgpack {
+		external = append(external, "github.com/vmihailenco/msgpack/v5")
+	}
+	if g.bson != "" {
+		external = append(external, "go.mongodb.org/mongo-driver/v2/bson")
+	}
+	if g.zap {
+		external = append(external, "go.uber.org/zap/zapcore")
+	}
+	if g.yaml {
+		external = append(external, "gopkg.in/yaml.v3")
+	}
+	if g.fold && g.canonicalLookup() {
+		external = append(external, "golang.org/x/text/unicode/norm")
+	}
+	if g.message {
+		external = append(external, "golang.org/x/text/message", "golang.org/x/text/message/catalog")
+	}
+	if g.message || g.label {
+		external = append(external, "golang.org/x/text/language")
+	}
+	if len(external) > 0 || g.protoPath != "" || g.from != nil {
+		g.Printf("\n")
+		for _, path := range external {
+			g.Printf("%q\n", path)
+		}
+		if g.from != nil {
+			g.Printf("%s %q\n", g.from.name, g.from.types.Path())
+		}
+		if g.protoPath != "" {
+			// The package name of generated protobuf code often differs from its path.
+			pkgName, _, _ := strings.Cut(g.proto, ".")
+			g.Printf("%s %q\n", pkgName, g.protoPath)
+		}
+	}
 	g.Printf(")\n")
 }
 
@@ -224,27 +559,60 @@ func (g *Generator) declareNameVars(runs [][]Value, typeName string, suffix stri
 	g.Printf("\"\n")
 }
 
+// needLookup reports whether any of the requested methods parse names
+// using the lookup function.
+func (g *Generator) needLookup() bool {
+	return g.lookupWrapper.name != "" || g.lookupBytes || g.registry || g.parse != "" || g.json != "" || g.text || g.sql || g.yaml || g.xml || g.bson != "" || g.cbor || g.msgpack || g.gob != "" || g.jsonv2 != "" || g.flagValue || g.mapstructure || g.validator
+}
+
+// needIsValid reports whether any of the requested methods check
+// whether a value is defined.
+func (g *Generator) needIsValid() bool {
+	return g.isValid || g.validate || g.debugString || g.templateFuncs || g.checkNumbers || g.parseNumbers == "defined" || g.slog || g.sql || g.proto != "" || g.validator
+}
+
 // genType produces the String method for the named type.
 func (g *Generator) genType(typeName string, values []Value) {
-	if g.json && g.lookup == "" {
+	if g.needLookup() && g.lookup == "" {
 		g.lookup = "_lookup_{}"
 	}
+	if g.from != nil {
+		g.buildFromPkg(typeName, values)
+	}
+	if values[0].isString {
+		g.genStringType(typeName, values)
+		return
+	}
 
-	g.buildCheck(values)
+	g.buildCheck(typeName, values)
 	if g.lookup != "" {
-		// For each value, you'll get 4 lines of source-code. This
-		// might overfloat the resulting file and we're choosing to
-		// generate a less verbose technique.
-		switch n := len(values); {
-		case n <= 500:
-			g.buildLookup(typeName, values) // fnv32 hash-switch
-		case n <= 5000:
-			g.buildLookupBinary(typeName, values) // binary search
+		names := g.lookupValues(values)
+		if g.canonicalize() {
+			g.buildCanonical(typeName)
+		}
+		switch g.strategy(len(names)) {
+		case "hash":
+			g.buildLookup(typeName, names) // fnv32 hash-switch
+		case "perfect":
+			g.buildLookupPerfect(typeName, names) // minimal perfect hash
+		case "binary":
+			g.buildLookupBinary(typeName, names) // binary search
 		default:
-			g.buildLookupMap(typeName, values) // map
+			g.buildLookupMap(typeName, names) // map
+		}
+		if g.flags {
+			g.buildLookupFlags(typeName)
 		}
 	}
-	runs := splitIntoRuns(values)
+	if g.lookupBytes && (g.canonicalize() || g.flags) {
+		g.buildLookupBytes(typeName)
+	}
+	if g.lookupWrapper.name != "" {
+		g.buildLookupWrapper(typeName)
+	}
+	// splitIntoRuns drops constants sharing a value, which are still
+	// needed by everything accepting names.
+	runs := splitIntoRuns(slices.Clone(values))
 
 	// The decision of which pattern to use depends on the number of
 	// runs in the numbers. If there's only one, it's easy. For more than
@@ -256,9 +624,13 @@ func (g *Generator) genType(typeName string, values []Value) {
 	// rather than use yet another algorithm such as binary search,
 	// we punt and use a map. In any case, the likelihood of a map
 	// being necessary for any realistic example other than bitmasks
-	// is very low. And bitmasks probably deserve their own analysis,
-	// to be done some other day.
+	// is very low. And bitmasks have their own analysis with -flags.
+	if !g.flags && looksLikeFlags(runs) {
+		log.Printf("warning: the values of %s look like flags, use -flags to print their combinations", typeName)
+	}
 	switch {
+	case g.flags:
+		g.buildFlags(runs, typeName)
 	case len(runs) == 1:
 		g.buildOneRun(runs, typeName)
 	case len(runs) <= 10:
@@ -266,90 +638,730 @@ func (g *Generator) genType(typeName string, values []Value) {
 	default:
 		g.buildMap(runs, typeName)
 	}
-	if g.json {
-		g.buildJson(typeName)
+	if g.needIsValid() {
+		g.buildIsValid(runs, typeName)
+	}
+	if g.validate {
+		g.buildValidate(typeName, values[0].signed)
+	}
+	if g.isValid {
+		g.Printf("\n")
+		g.Printf("// IsValid reports whether i is a defined value of %s.\n", typeName)
+		g.Printf("func (i %s) IsValid() bool {\n", typeName)
+		g.Printf("return _isValid_%s(i)\n", typeName)
+		g.Printf("}\n")
+	}
+	if g.parse != "" {
+		g.buildParse(values, typeName)
+	}
+	if g.count != "" {
+		g.buildCount(runs, typeName)
+	}
+	if g.minMax {
+		g.buildMinMax(runs, typeName)
+	}
+	if g.values {
+		g.buildValues(runs, typeName)
+	}
+	if g.options {
+		g.buildOptions(runs, typeName)
+	}
+	if g.usage {
+		g.buildUsage(runs, typeName)
+	}
+	if g.templateFuncs {
+		g.buildTemplateFuncs(typeName)
+	}
+	if g.catalogs != nil {
+		g.buildI18n(runs, typeName)
+	}
+	if g.message {
+		g.buildMessage(runs, typeName)
+	}
+	if g.label {
+		g.buildLabel(runs, typeName)
+	}
+	if g.iter != "" {
+		g.buildIter(runs, typeName)
+	}
+	if g.next != "" {
+		g.buildNext(runs, typeName)
+	}
+	if g.clamp {
+		g.buildClamp(runs, typeName)
+	}
+	if g.wireName != "" {
+		g.buildWireName(runs, typeName)
+	}
+	if g.fingerprint {
+		g.buildFingerprint(runs, typeName)
+	}
+	if g.debugString {
+		g.Printf("\n")
+		g.Printf("// DebugString returns the name of i qualified by its type, as in \"%s.%s\".\n", typeName, runs[0][0].repr)
+		g.Printf("func (i %s) DebugString() string {\n", typeName)
+		g.Printf("if !_isValid_%s(i) {\n", typeName)
+		g.Printf("return i.String()\n")
+		g.Printf("}\n")
+		g.Printf("return \"%s.\" + i.String()\n", typeName)
+		g.Printf("}\n")
+	}
+	if g.predicates {
+		g.buildPredicates(values, typeName)
+	}
+	if len(g.ranges[typeName]) > 0 {
+		g.buildRanges(values, typeName)
+	}
+	if g.enums {
+		g.buildEnums(runs, typeName)
+	}
+	if g.registry {
+		g.buildRegistry(typeName)
+	}
+	if g.runtime {
+		g.buildRuntime(runs, typeName)
+	}
+	if g.ordinal {
+		g.buildOrdinal(runs, typeName)
+	}
+	if g.deprecated != "" {
+		g.buildDeprecated(values, typeName)
+	}
+	if g.category {
+		g.buildCategory(runs, typeName)
+	}
+	if g.meta {
+		g.buildMeta(runs, typeName)
+	}
+	if g.random {
+		g.buildRandom(runs, typeName)
+	}
+	if g.set {
+		g.buildSet(runs, typeName)
+	}
+	if g.enumMap {
+		g.buildEnumMap(runs, typeName)
+	}
+	if g.slice {
+		values, _ := g.listing(runs, typeName)
+		g.buildSlice(values, typeName, g.order())
+	}
+	if g.json != "" {
+		g.buildJson(runs, typeName)
+	}
+	if g.jsonv2 != "" {
+		g.buildJsonV2(typeName, values[0].signed)
+	}
+	if g.text {
+		g.buildText(typeName, values[0].signed)
+	}
+	if g.sql {
+		g.buildSql(typeName, values[0].signed)
+	}
+	if g.sqlnull {
+		g.buildSqlNull(typeName)
+	}
+	if g.yaml {
+		g.buildYaml(typeName)
+	}
+	if g.xml {
+		g.buildXml(typeName)
+	}
+	if g.bson != "" {
+		g.buildBson(typeName, values[0].signed)
+	}
+	if g.cbor {
+		g.buildCbor(typeName)
+	}
+	if g.msgpack {
+		g.buildMsgpack(typeName)
+	}
+	if g.binary {
+		g.buildBinary(runs, typeName)
+	}
+	if g.gob != "" {
+		g.buildGob(typeName)
+	}
+	if g.graphql {
+		g.buildGraphql(runs, typeName)
+	}
+	if g.slog {
+		g.buildSlog(typeName, values[0].signed)
+	}
+	if g.zap {
+		g.buildZap(typeName, values[0].signed)
+	}
+	if g.formatter {
+		g.buildFormatter(typeName, values[0].signed)
+	}
+	if g.gostring {
+		g.buildGoString(runs, typeName)
+	}
+	if g.flagValue {
+		g.buildFlagValue(runs, typeName)
+	}
+	if g.cobra {
+		g.buildCobra(runs, typeName)
+	}
+	if g.kong {
+		g.buildKong(typeName)
+	}
+	if g.mapstructure {
+		g.buildMapstructure(typeName)
+	}
+	if g.error != "" {
+		g.buildError(runs, typeName)
+	}
+	if g.validator {
+		g.buildValidator(typeName)
+	}
+	if g.prometheus {
+		g.buildPrometheus(runs, typeName)
+	}
+	if g.proto != "" {
+		g.buildProto(runs, typeName)
 	}
 }
 
-func (g *Generator) buildCheck(values []Value) {
-	// Generate code that will fail if the constants change value.
-	g.Printf("func _() {\n")
-	g.Printf("// An \"invalid array index\" compiler error signifies that the constant values have changed.\n")
-	g.Printf("// Re-run the stringer command to generate them again.\n")
-	g.Printf("var x [1]struct{}\n")
+// buildFromPkg declares the local copy of a type of the package given by
+// -frompkg, and its constants, which the generated methods use.
+func (g *Generator) buildFromPkg(typeName string, values []Value) {
+	g.Printf("\n")
+	g.Printf("// %s is %s.%s, declared here to have the generated methods.\n", typeName, g.from.name, typeName)
+	g.Printf("type %s %s.%s\n", typeName, g.from.name, typeName)
+	g.Printf("\n")
+	g.Printf("const (\n")
 	for _, v := range values {
-		g.Printf("_ = x[%s - %s]\n", v.original, v.str)
+		g.Printf("%s = %s(%s.%s)\n", v.original, typeName, g.from.name, v.original)
 	}
-	g.Printf("}\n")
-}
-
-// buildOneRun generates the variables and String method for a single run of contiguous values.
-func (g *Generator) buildOneRun(runs [][]Value, typeName string) {
-	values := runs[0]
+	g.Printf(")\n")
 	g.Printf("\n")
-	g.declareIndexAndNameVar(values, typeName)
-	g.Printf(stringOneRun, typeName, values[0].String())
 }
 
-// Arguments to format are:
-//
-//	[1]: type name
-//	[2]: lowest defined value for type, as a string
-const stringOneRun = `func (i %[1]s) String() string {
-	idx := int(i) - %[2]s
-	if i < %[2]s || idx >= len(_%[1]s_index)-1 {
-		return "%[1]s(" + strconv.FormatInt(int64(i), 10) + ")"
+// stringTypeOption returns the first option set which does not apply to
+// string types, which have neither order nor bits, or "" if there is none.
+func (g *Generator) stringTypeOption() string {
+	options := []struct {
+		name string
+		set  bool
+	}{
+		{"lookup with a signature", g.lookupWrapper.name != ""},
+		{"lookupbytes", g.lookupBytes},
+		{"fold", g.fold},
+		{"ignoresep", g.ignoreSep},
+		{"parsenumbers", g.parseNumbers != ""},
+		{"options", g.options},
+		{"usage", g.usage},
+		{"flags", g.flags},
+		{"i18n", g.catalogs != nil},
+		{"message", g.message},
+		{"label", g.label},
+		{"iter", g.iter != ""},
+		{"next", g.next != ""},
+		{"ordinal", g.ordinal},
+		{"deprecated", g.deprecated != ""},
+		{"category", g.category},
+		{"meta", g.meta},
+		{"random", g.random},
+		{"set", g.set},
+		{"enummap", g.enumMap},
+		{"validate", g.validate},
+		{"clamp", g.clamp},
+		{"predicates", g.predicates},
+		{"enums", g.enums},
+		{"runtime", g.runtime},
+		{"wirename", g.wireName != ""},
+		{"debugstring", g.debugString},
+		{"fingerprint", g.fingerprint},
+		{"count", g.count != ""},
+		{"minmax", g.minMax},
+		{"registry", g.registry},
+		{"json=number", g.json == "number"},
+		{"sql", g.sql},
+		{"sqlnull", g.sqlnull},
+		{"yaml", g.yaml},
+		{"xml", g.xml},
+		{"bson", g.bson != ""},
+		{"cbor", g.cbor},
+		{"msgpack", g.msgpack},
+		{"binary", g.binary},
+		{"gob", g.gob != ""},
+		{"jsonv2", g.jsonv2 != ""},
+		{"graphql", g.graphql},
+		{"slog", g.slog},
+		{"zap", g.zap},
+		{"formatter", g.formatter},
+		{"gostring", g.gostring},
+		{"flagvalue", g.flagValue},
+		{"cobra", g.cobra},
+		{"kong", g.kong},
+		{"mapstructure", g.mapstructure},
+		{"error", g.error != ""},
+		{"validator", g.validator},
+		{"prometheus", g.prometheus},
+		{"proto", g.proto != ""},
+		{"unknown=number", g.unknown == "number" && (g.text || g.json != "")},
 	}
-	return _%[1]s_name[_%[1]s_index[idx] : _%[1]s_index[idx+1]]
+	for _, opt := range options {
+		if opt.set {
+			return opt.name
+		}
+	}
+	return ""
 }
-`
 
-// buildMultipleRuns generates the variables and String method for multiple runs of contiguous values.
-// For this pattern, a single Printf format won't do.
-func (g *Generator) buildMultipleRuns(runs [][]Value, typeName string) {
+// genStringType produces the methods for a type of string constants.
+// The value of each constant is its name unless given otherwise, and
+// the constants keep the order of their declaration.
+func (g *Generator) genStringType(typeName string, values []Value) {
+	if opt := g.stringTypeOption(); opt != "" {
+		failf("-%s does not apply to the string type %s", opt, typeName)
+	}
+	// Constants sharing a value are printed by the first of them.
+	var unique []Value
+	for _, v := range values {
+		if !slices.ContainsFunc(unique, func(u Value) bool { return u.str == v.str }) {
+			unique = append(unique, v)
+		}
+	}
+
+	g.Printf("func _() {\n")
+	g.Printf("// A \"duplicate key\" compiler error signifies that the constant values have changed.\n")
+	g.Printf("// Re-run the stringer command to generate them again.\n")
+	for _, v := range values {
+		g.Printf("_ = map[bool]int{false: 0, %s == %s: 0}\n", v.original, v.str)
+	}
+	g.Printf("}\n")
+
+	if g.lookup != "" {
+		g.buildStringLookup(values, typeName)
+	}
 	g.Printf("\n")
-	g.declareIndexAndNameVars(runs, typeName)
 	g.Printf("func (i %s) String() string {\n", typeName)
-	g.Printf("switch {\n")
-	for i, values := range runs {
-		if len(values) == 1 {
-			g.Printf("case i == %s:\n", &values[0])
-			g.Printf("return _%s_name_%d\n", typeName, i)
-			continue
+	g.Printf("switch i {\n")
+	for _, v := range unique {
+		g.Printf("case %s:\n", v.original)
+		g.Printf("return %q\n", v.repr)
+	}
+	g.Printf("}\n")
+	g.Printf("return \"%s(\" + strconv.Quote(string(i)) + \")\"\n", typeName)
+	g.Printf("}\n")
+	if g.needIsValid() {
+		g.Printf("\n")
+		g.Printf("func _isValid_%s(i %s) bool {\n", typeName, typeName)
+		g.Printf("switch i {\n")
+		g.Printf("case ")
+		for i, v := range unique {
+			if i > 0 {
+				g.Printf(", ")
+			}
+			g.Printf("%s", v.original)
 		}
-		if values[0].value == 0 && !values[0].signed {
-			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
-			g.Printf("case i <= %s:\n", &values[len(values)-1])
-		} else {
-			g.Printf("case %s <= i && i <= %s:\n", &values[0], &values[len(values)-1])
+		g.Printf(":\n")
+		g.Printf("return true\n")
+		g.Printf("}\n")
+		g.Printf("return false\n")
+		g.Printf("}\n")
+	}
+	if g.isValid {
+		g.Printf("\n")
+		g.Printf("// IsValid reports whether i is a defined value of %s.\n", typeName)
+		g.Printf("func (i %s) IsValid() bool {\n", typeName)
+		g.Printf("return _isValid_%s(i)\n", typeName)
+		g.Printf("}\n")
+	}
+	if g.parse != "" {
+		g.buildStringParse(values, typeName)
+	}
+	if g.values {
+		g.Printf("\n")
+		g.Printf("// %sValues returns all values of %s in the order of their declaration.\n", typeName, typeName)
+		g.Printf("func %sValues() []%s {\n", typeName, typeName)
+		g.Printf("return []%s{", typeName)
+		for _, v := range unique {
+			g.Printf("%s, ", v.original)
 		}
-		if values[0].value != 0 {
-			g.Printf("i -= %s\n", &values[0])
+		g.Printf("}\n")
+		g.Printf("}\n")
+		g.Printf("\n")
+		g.Printf("// %sNames returns the names of all values of %s, in the order of %sValues.\n", typeName, typeName, typeName)
+		g.Printf("func %sNames() []string {\n", typeName)
+		g.Printf("return []string{")
+		for _, v := range unique {
+			g.Printf("%q, ", v.repr)
 		}
-		g.Printf("return _%s_name_%d[_%s_index_%d[i]:_%s_index_%d[i+1]]\n",
-			typeName, i, typeName, i, typeName, i)
+		g.Printf("}\n")
+		g.Printf("}\n")
+	}
+	if g.templateFuncs {
+		g.buildTemplateFuncs(typeName)
+	}
+	if g.slice {
+		g.buildSlice(unique, typeName, "in the order of their declaration")
+	}
+	if g.json != "" {
+		g.buildStringJson(typeName)
+	}
+	if g.text {
+		g.buildText(typeName, false)
+	}
+}
+
+// buildStringLookup generates the lookup of a string type, accepting the
+// names, their aliases, the values and the identifiers of the constants.
+func (g *Generator) buildStringLookup(values []Value, typeName string) {
+	var names []Value
+	taken := make(map[string]bool)
+	add := func(name string, v Value) {
+		if !taken[name] {
+			taken[name] = true
+			v.repr = name
+			names = append(names, v)
+		}
+	}
+	for _, v := range values {
+		add(v.repr, v)
+		for _, alias := range v.aliases {
+			add(alias, v)
+		}
+	}
+	// Values printed differently are accepted as well, as the identifiers.
+	for _, v := range values {
+		value, _ := strconv.Unquote(v.str)
+		add(value, v)
+		add(v.original, v)
+	}
+	miss := missValue(values)
+	if miss == "0" {
+		miss = `""`
+	}
+	g.Printf("\n")
+	g.Printf("func %s(name string) (%s, bool) {\n", strings.Replace(g.lookup, "{}", typeName, 1), typeName)
+	g.Printf("switch name {\n")
+	for _, v := range names {
+		g.Printf("case %q:\n", v.repr)
+		g.Printf("return %s, true\n", v.original)
 	}
-	g.Printf("default:\n")
-	g.Printf("return \"%s(\" + strconv.FormatInt(int64(i), 10) + \")\"\n", typeName)
 	g.Printf("}\n")
+	g.Printf("return %s, false\n", miss)
 	g.Printf("}\n")
 }
 
-// Argument to format is the type name.
-const stringMap = `func (i %[1]s) String() string {
-	if str, ok := _%[1]s_map[i]; ok {
-		return str
+// buildStringParse generates ParseT for a string type, as buildParse does
+// for integer types.
+func (g *Generator) buildStringParse(values []Value, typeName string) {
+	g.buildSuggest(values, typeName)
+	g.Printf("\n")
+	g.Printf("// ErrInvalid%s is wrapped by the error of Parse%s for unknown names.\n", typeName, typeName)
+	g.Printf("var ErrInvalid%s = errors.New(\"invalid %s\")\n", typeName, typeName)
+	g.Printf("\n")
+	g.Printf("// Parse%s returns the %s with the given name.\n", typeName, typeName)
+	g.Printf("func Parse%s(name string) (%s, error) {\n", typeName, typeName)
+	g.Printf("i, ok := %s(name)\n", strings.Replace(g.lookup, "{}", typeName, 1))
+	g.Printf("if !ok {\n")
+	g.Printf("if s := _suggest_%s(name); s != \"\" {\n", typeName)
+	g.Printf("return \"\", fmt.Errorf(\"%%w: %%q, did you mean %%q?\", ErrInvalid%s, name, s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return \"\", fmt.Errorf(\"%%w: %%q\", ErrInvalid%s, name)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return i, nil\n")
+	g.Printf("}\n")
+	if g.parse == "must" {
+		g.Printf("\n")
+		g.Printf("// MustParse%s is like Parse%s but panics for unknown names.\n", typeName, typeName)
+		g.Printf("func MustParse%s(name string) %s {\n", typeName, typeName)
+		g.Printf("i, err := Parse%s(name)\n", typeName)
+		g.Printf("if err != nil {\n")
+		g.Printf("panic(err)\n")
+		g.Printf("}\n")
+		g.Printf("return i\n")
+		g.Printf("}\n")
 	}
-	return "%[1]s(" + strconv.FormatInt(int64(i), 10) + ")"
 }
-`
 
-// buildMap handles the case where the space is so sparse a map is a reasonable fallback.
-// It's a rare situation but has simple code.
-func (g *Generator) buildMap(runs [][]Value, typeName string) {
+// buildStringJson generates the JSON methods of a string type, which are
+// marshaled by their name only.
+func (g *Generator) buildStringJson(typeName string) {
 	g.Printf("\n")
-	g.declareNameVars(runs, typeName, "")
-	g.Printf("\nvar _%s_map = map[%s]string{\n", typeName, typeName)
+	g.Printf("func (i %s) MarshalJSON() ([]byte, error) {\n", typeName)
+	g.Printf("return json.Marshal(i.String())\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalJSON(b []byte) error {\n", typeName)
+	typeError := fmt.Sprintf("&json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(%s(\"\"))}", typeName)
+	g.Printf("var value any\n")
+	g.Printf("if err := json.Unmarshal(b, &value); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("s, ok := value.(string)\n")
+	g.Printf("if !ok {\n")
+	g.Printf("return %s\n", typeError)
+	g.Printf("}\n")
+	g.buildUnknown(typeName, "s", typeError, false)
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildCheck(typeName string, values []Value) {
+	// Generate code that will fail if the constants change value.
+	g.Printf("func _() {\n")
+	g.Printf("// An \"invalid array index\" compiler error signifies that the constant values have changed.\n")
+	g.Printf("// Re-run the stringer command to generate them again.\n")
+	g.Printf("var x [1]struct{}\n")
+	for _, v := range values {
+		g.Printf("_ = x[%s - %s]\n", v.original, v.str)
+	}
+	for _, s := range g.sentinels[typeName] {
+		g.buildSentinelCheck(s, values)
+	}
+	g.Printf("}\n")
+}
+
+// buildSentinelCheck generates the part of the check relying on the
+// constant counting the values. The count changes when a constant is added
+// or removed, and all constants must index an array of that size, once.
+func (g *Generator) buildSentinelCheck(s sentinel, values []Value) {
+	g.Printf("// An \"invalid array index\" compiler error signifies that constants were added or removed.\n")
+	g.Printf("_ = x[%s - %s]\n", s.name, s.value)
+	count, ok := constant.Int64Val(s.value)
+	if !ok || count < 0 {
+		return
+	}
+	var keys []string
+	seen := make(map[uint64]bool)
+	for _, v := range values {
+		if v.signed && int64(v.value) < 0 || v.value >= uint64(count) {
+			// The array can not be indexed by all constants.
+			return
+		}
+		if !seen[v.value] {
+			seen[v.value] = true
+			keys = append(keys, v.original+": {}")
+		}
+	}
+	g.Printf("_ = [%s]struct{}{%s}\n", s.name, strings.Join(keys, ", "))
+}
+
+// formatInt returns the expression formatting x in decimal. Unsigned types
+// are formatted as uint64, so that values above MaxInt64 are not negative.
+func formatInt(x string, signed bool) string {
+	if signed {
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", x)
+	}
+	return fmt.Sprintf("strconv.FormatUint(uint64(%s), 10)", x)
+}
+
+// intType returns the integer type holding any value of a signed or
+// unsigned type, "int64" or "uint64".
+func intType(signed bool) string {
+	if signed {
+		return "int64"
+	}
+	return "uint64"
+}
+
+// parseInt returns the expression parsing the decimal number s, into an
+// int64 or uint64 like intType.
+func parseInt(s string, signed bool) string {
+	if signed {
+		return fmt.Sprintf("strconv.ParseInt(%s, 10, 64)", s)
+	}
+	return fmt.Sprintf("strconv.ParseUint(%s, 10, 64)", s)
+}
+
+// buildOneRun generates the variables and String method for a single run of contiguous values.
+func (g *Generator) buildOneRun(runs [][]Value, typeName string) {
+	values := runs[0]
+	g.Printf("\n")
+	g.declareIndexAndNameVar(values, typeName)
+	g.Printf(stringOneRun, typeName, values[0].String(), formatInt("i", values[0].signed))
+}
+
+// Arguments to format are:
+//
+//	[1]: type name
+//	[2]: lowest defined value for type, as a string
+//	[3]: expression formatting i, as given by formatInt
+const stringOneRun = `func (i %[1]s) String() string {
+	idx := int(i) - %[2]s
+	if i < %[2]s || idx >= len(_%[1]s_index)-1 {
+		return "%[1]s(" + %[3]s + ")"
+	}
+	return _%[1]s_name[_%[1]s_index[idx] : _%[1]s_index[idx+1]]
+}
+`
+
+// buildMultipleRuns generates the variables and String method for multiple runs of contiguous values.
+// For this pattern, a single Printf format won't do.
+func (g *Generator) buildMultipleRuns(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.declareIndexAndNameVars(runs, typeName)
+	g.Printf("func (i %s) String() string {\n", typeName)
+	g.Printf("switch {\n")
+	for i, values := range runs {
+		if len(values) == 1 {
+			g.Printf("case i == %s:\n", &values[0])
+			g.Printf("return _%s_name_%d\n", typeName, i)
+			continue
+		}
+		if values[0].value == 0 && !values[0].signed {
+			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
+			g.Printf("case i <= %s:\n", &values[len(values)-1])
+		} else {
+			g.Printf("case %s <= i && i <= %s:\n", &values[0], &values[len(values)-1])
+		}
+		if values[0].value != 0 {
+			g.Printf("i -= %s\n", &values[0])
+		}
+		g.Printf("return _%s_name_%d[_%s_index_%d[i]:_%s_index_%d[i+1]]\n",
+			typeName, i, typeName, i, typeName, i)
+	}
+	g.Printf("default:\n")
+	g.Printf("return \"%s(\" + %s + \")\"\n", typeName, formatInt("i", runs[0][0].signed))
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// flagValues returns the values of the runs which are a single bit, in
+// ascending order.
+func flagValues(runs [][]Value) []Value {
+	var flags []Value
+	for _, values := range runs {
+		for _, v := range values {
+			if v.value != 0 && v.value&(v.value-1) == 0 {
+				flags = append(flags, v)
+			}
+		}
+	}
+	return flags
+}
+
+// looksLikeFlags reports whether the values of the runs look like
+// combinations of flags: at least three single bits, every value made of
+// them, and holes between the values. Without holes a type with values
+// 0 to 7 would look like flags.
+func looksLikeFlags(runs [][]Value) bool {
+	flags := flagValues(runs)
+	if len(flags) < 3 || len(runs) == 1 {
+		return false
+	}
+	var all uint64
+	for _, v := range flags {
+		all |= v.value
+	}
+	for _, values := range runs {
+		for _, v := range values {
+			if v.value&^all != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// buildFlags generates the String method for types whose values are
+// combinations of flags, joining the names of the set bits with the
+// separator. Values which are not a single bit besides 0 are ignored, and
+// bits without a name are printed as a number at the end, in hexadecimal
+// unless -flagunknown asks for decimal or the type.
+func (g *Generator) buildFlags(runs [][]Value, typeName string) {
+	flags := flagValues(runs)
+	signed := runs[0][0].signed
+	zero := fmt.Sprintf("%q", typeName+"(0)")
+	if v := runs[0][0]; v.value == 0 {
+		zero = fmt.Sprintf("%q", v.repr)
+	}
+	g.Printf("\n")
+	if len(flags) > 0 {
+		g.declareIndexAndNameVar(flags, typeName)
+		g.Printf("var _%s_bits = [...]%s{", typeName, typeName)
+		for i, v := range flags {
+			if i > 0 {
+				g.Printf(", ")
+			}
+			g.Printf("%s", v.original)
+		}
+		g.Printf("}\n")
+		g.Printf("\n")
+	}
+	g.Printf("// String returns the names of the flags set in i joined by %q.\n", g.flagSep)
+	g.Printf("func (i %s) String() string {\n", typeName)
+	g.Printf("if i == 0 {\n")
+	g.Printf("return %s\n", zero)
+	g.Printf("}\n")
+	g.Printf("var s string\n")
+	if len(flags) > 0 {
+		g.Printf("for n, bit := range _%s_bits {\n", typeName)
+		g.Printf("if i&bit == 0 {\n")
+		g.Printf("continue\n")
+		g.Printf("}\n")
+		g.Printf("if s != \"\" {\n")
+		g.Printf("s += %q\n", g.flagSep)
+		g.Printf("}\n")
+		g.Printf("s += _%s_name[_%s_index[n]:_%s_index[n+1]]\n", typeName, typeName, typeName)
+		g.Printf("i &^= bit\n")
+		g.Printf("}\n")
+	}
+	g.Printf("if i != 0 {\n")
+	g.Printf("if s != \"\" {\n")
+	g.Printf("s += %q\n", g.flagSep)
+	g.Printf("}\n")
+	switch g.flagUnknown {
+	case "decimal":
+		g.Printf("s += %s\n", formatInt("i", signed))
+	case "type":
+		g.Printf("s += \"%s(\" + %s + \")\"\n", typeName, formatInt("i", signed))
+	default:
+		g.Printf("s += \"0x\" + strconv.FormatUint(uint64(i), 16)\n")
+	}
+	g.Printf("}\n")
+	g.Printf("return s\n")
+	g.Printf("}\n")
+
+	var all []string
+	for _, values := range runs {
+		for _, v := range values {
+			if v.value != 0 {
+				all = append(all, v.original)
+			}
+		}
+	}
+	if len(all) == 0 {
+		all = append(all, "0")
+	}
+	g.Printf("\n")
+	g.Printf("// _%sAll holds the bits of all constants of %s.\n", typeName, typeName)
+	g.Printf("const _%sAll = %s\n", typeName, strings.Join(all, " | "))
+	g.Printf("\n")
+	g.Printf("// IsSubsetOf reports whether all bits set in i are set in mask.\n")
+	g.Printf("func (i %s) IsSubsetOf(mask %s) bool {\n", typeName, typeName)
+	g.Printf("return i&^mask == 0\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Invalid returns the bits set in i which are not part of a constant of %s.\n", typeName)
+	g.Printf("func (i %s) Invalid() %s {\n", typeName, typeName)
+	g.Printf("return i &^ _%sAll\n", typeName)
+	g.Printf("}\n")
+}
+
+// Arguments to format are:
+//
+//	[1]: type name
+//	[2]: expression formatting i, as given by formatInt
+const stringMap = `func (i %[1]s) String() string {
+	if str, ok := _%[1]s_map[i]; ok {
+		return str
+	}
+	return "%[1]s(" + %[2]s + ")"
+}
+`
+
+// buildMap handles the case where the space is so sparse a map is a reasonable fallback.
+// It's a rare situation but has simple code.
+func (g *Generator) buildMap(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.declareNameVars(runs, typeName, "")
+	g.Printf("\nvar _%s_map = map[%s]string{\n", typeName, typeName)
 	n := 0
 	for _, values := range runs {
 		for _, value := range values {
@@ -358,23 +1370,191 @@ func (g *Generator) buildMap(runs [][]Value, typeName string) {
 		}
 	}
 	g.Printf("}\n\n")
-	g.Printf(stringMap, typeName)
+	g.Printf(stringMap, typeName, formatInt("i", runs[0][0].signed))
 }
 
-func (g *Generator) buildLookup(typeName string, values []Value) {
+// buildIsValid generates a function reporting whether a value is defined,
+// checking the same runs as the String method.
+func (g *Generator) buildIsValid(runs [][]Value, typeName string) {
 	g.Printf("\n")
-
-	funcName := strings.Replace(g.lookup, "{}", typeName, 1)
-	g.Printf("func %s(name string) (%s, bool) {\n", funcName, typeName)
-	g.Printf("//fnv1a32 hash\n")
-	g.Printf("var h uint32 = 2166136261\n")
-	g.Printf("for i := 0; i < len(name); i++ {\n")
-	g.Printf("h ^= uint32(name[i])\n")
-	g.Printf("h *= 16777619\n")
+	g.Printf("func _isValid_%s(i %s) bool {\n", typeName, typeName)
+	g.Printf("switch {\n")
+	g.Printf("case ")
+	for i, values := range runs {
+		if i > 0 {
+			g.Printf(",\n")
+		}
+		switch {
+		case len(values) == 1:
+			g.Printf("i == %s", &values[0])
+		case values[0].value == 0 && !values[0].signed:
+			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
+			g.Printf("i <= %s", &values[len(values)-1])
+		default:
+			g.Printf("%s <= i && i <= %s", &values[0], &values[len(values)-1])
+		}
+	}
+	g.Printf(":\n")
+	g.Printf("return true\n")
+	g.Printf("}\n")
+	g.Printf("return false\n")
 	g.Printf("}\n")
+}
+
+// Default thresholds of the automatic lookup strategy.
+const (
+	defaultHashMax   = 500
+	defaultBinaryMax = 5000
+)
+
+// strategy returns the lookup strategy for n names. For each value, the
+// hash-switch takes 4 lines of source-code. This might overfloat the
+// resulting file, so larger types use a less verbose technique.
+func (g *Generator) strategy(n int) string {
+	hashMax, binaryMax := g.hashMax, g.binaryMax
+	switch g.lookupStrategy {
+	case "":
+		hashMax, binaryMax = defaultHashMax, defaultBinaryMax
+	case "auto":
+	default:
+		return g.lookupStrategy
+	}
+	switch {
+	case n <= hashMax:
+		return "hash"
+	case n <= binaryMax:
+		return "binary"
+	default:
+		return "map"
+	}
+}
+
+// lookupValues returns a copy of values with an additional entry for
+// each alias, and each identifier or wire name differing from the printed
+// name, so the lookup accepts them as well. The names are
+// brought into the form produced by the canonicalization of the lookup.
+func (g *Generator) lookupValues(values []Value) []Value {
+	names := slices.Clone(values)
+	for _, v := range values {
+		for _, alias := range v.aliases {
+			for _, other := range values {
+				if other.value != v.value && (other.repr == alias || slices.Contains(other.aliases, alias)) {
+					failf("alias %s of %s is also a name of %s", alias, v.original, other.original)
+				}
+			}
+			a := v
+			a.repr = alias
+			a.aliases = nil
+			names = append(names, a)
+		}
+	}
+	// Accept the identifiers of constants printed differently, and their
+	// wire names, unless they are already a name, possibly of another value.
+	taken := make(map[string]bool, len(names))
+	for _, v := range names {
+		taken[v.repr] = true
+	}
+	for _, v := range values {
+		others := []string{v.original}
+		if g.wireName != "" {
+			others = append(others, g.wireNameOf(v))
+		}
+		for _, name := range others {
+			if taken[name] {
+				continue
+			}
+			taken[name] = true
+			a := v
+			a.repr = name
+			a.aliases = nil
+			names = append(names, a)
+		}
+	}
+	if !g.canonicalize() {
+		return names
+	}
+	for i := range names {
+		names[i].repr = g.canonical(names[i].repr)
+	}
+	seen := make(map[string]Value)
+	return slices.DeleteFunc(names, func(v Value) bool {
+		prev, dup := seen[v.repr]
+		if dup && prev.value != v.value {
+			failf("%s and %s are indistinguishable in the lookup", prev.original, v.original)
+		}
+		seen[v.repr] = v
+		return dup
+	})
+}
+
+// canonicalize reports whether the lookup canonicalizes names before
+// matching them. Ignoring separators implies ignoring case, so "not_found"
+// matches NotFound.
+func (g *Generator) canonicalize() bool {
+	return g.fold || g.ignoreSep
+}
+
+// canonicalLookup reports whether the canonicalization of the lookup is
+// generated, for the imports: only integer types have it, and only when a
+// lookup is generated.
+func (g *Generator) canonicalLookup() bool {
+	return g.canonicalize() && g.intTypes && (g.lookup != "" || g.needLookup())
+}
+
+// canonical returns name as the generated canonicalization of the lookup
+// does. Names are assumed to be in NFC already, as is usual for Go source.
+func (g *Generator) canonical(name string) string {
+	if !g.canonicalize() {
+		return name
+	}
+	return strings.Map(func(r rune) rune {
+		if g.ignoreSep && isSeparator(r) {
+			return -1
+		}
+		return foldRune(r)
+	}, name)
+}
+
+// isSeparator reports whether r is ignored by the lookup with -ignoresep.
+func isSeparator(r rune) bool {
+	return r == '-' || r == '_' || r == ' '
+}
+
+// foldRune maps r to the smallest rune it is equivalent to under simple
+// Unicode case folding.
+func foldRune(r rune) rune {
+	m := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		m = min(m, f)
+	}
+	return m
+}
+
+// buildCanonical generates the canonicalization applied to the input of the
+// lookup, matching Generator.canonical.
+func (g *Generator) buildCanonical(typeName string) {
 	g.Printf("\n")
-	g.Printf("switch h {\n")
+	g.Printf("func _canonical_%s(name string) string {\n", typeName)
+	if g.fold {
+		g.Printf("name = norm.NFC.String(name)\n")
+	}
+	g.Printf("return strings.Map(func(r rune) rune {\n")
+	if g.ignoreSep {
+		g.Printf("switch r {\n")
+		g.Printf("case '-', '_', ' ':\n")
+		g.Printf("return -1\n")
+		g.Printf("}\n")
+	}
+	g.Printf("m := r\n")
+	g.Printf("for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {\n")
+	g.Printf("m = min(m, f)\n")
+	g.Printf("}\n")
+	g.Printf("return m\n")
+	g.Printf("}, name)\n")
+	g.Printf("}\n")
+}
 
+func (g *Generator) buildLookup(typeName string, values []Value) {
 	// group by hash
 	type entry struct {
 		hash uint32
@@ -397,22 +1577,38 @@ func (g *Generator) buildLookup(typeName string, values []Value) {
 		return strings.Compare(left.name, right.name)
 	})
 
-	// emit switch cases, handling collisions
-	prev := uint32(0xffffffff)
-	for _, ent := range ents {
-		h := ent.hash
-		if h != prev {
-			g.Printf("case 0x%08x:\n", h)
-			prev = h
+	for _, fn := range g.lookupFuncs(typeName) {
+		g.Printf("\n")
+		g.Printf("func %s(name %s) (%s, bool) {\n", fn.name, fn.param, typeName)
+		if g.canonicalize() {
+			g.Printf("name = _canonical_%s(name)\n", typeName)
 		}
-		g.Printf("if name == %q {\n", ent.name)
-		g.Printf("return %s, true\n", ent.val)
+		g.Printf("//fnv1a32 hash\n")
+		g.Printf("var h uint32 = 2166136261\n")
+		g.Printf("for i := 0; i < len(name); i++ {\n")
+		g.Printf("h ^= uint32(name[i])\n")
+		g.Printf("h *= 16777619\n")
 		g.Printf("}\n")
-	}
+		g.Printf("\n")
+		g.Printf("switch h {\n")
 
-	g.Printf("}\n")
-	g.Printf("return 0, false\n")
-	g.Printf("}\n")
+		// emit switch cases, handling collisions
+		prev := uint32(0xffffffff)
+		for _, ent := range ents {
+			h := ent.hash
+			if h != prev {
+				g.Printf("case 0x%08x:\n", h)
+				prev = h
+			}
+			g.Printf("if %s == %q {\n", fn.str, ent.name)
+			g.Printf("return %s, true\n", ent.val)
+			g.Printf("}\n")
+		}
+
+		g.Printf("}\n")
+		g.Printf("return %s, false\n", missValue(values))
+		g.Printf("}\n")
+	}
 }
 
 func (g *Generator) buildLookupBinary(typeName string, values []Value) {
@@ -438,26 +1634,106 @@ func (g *Generator) buildLookupBinary(typeName string, values []Value) {
 	}
 	g.Printf("}\n\n")
 
-	funcName := strings.Replace(g.lookup, "{}", typeName, 1)
-	g.Printf("func %s(name string) (%s, bool) {\n", funcName, typeName)
+	for i, fn := range g.lookupFuncs(typeName) {
+		if i > 0 {
+			g.Printf("\n")
+		}
+		g.Printf("func %s(name %s) (%s, bool) {\n", fn.name, fn.param, typeName)
+		if g.canonicalize() {
+			g.Printf("name = _canonical_%s(name)\n", typeName)
+		}
 
-	g.Printf("lo, hi := 0, len(_%s_value_lookup)\n", typeName)
-	g.Printf("for lo < hi {\n")
-	g.Printf("mid := int(uint(lo+hi) >> 1)\n")
-	g.Printf("s := _%s_name_lookup[_%s_index_lookup[mid]:_%s_index_lookup[mid+1]]\n",
-		typeName, typeName, typeName)
+		g.Printf("lo, hi := 0, len(_%s_value_lookup)\n", typeName)
+		g.Printf("for lo < hi {\n")
+		g.Printf("mid := int(uint(lo+hi) >> 1)\n")
+		g.Printf("s := _%s_name_lookup[_%s_index_lookup[mid]:_%s_index_lookup[mid+1]]\n",
+			typeName, typeName, typeName)
 
-	g.Printf("if name == s {\n")
-	g.Printf("return _%s_value_lookup[mid], true\n", typeName)
-	g.Printf("}\n")
-	g.Printf("if name < s {\n")
-	g.Printf("hi = mid\n")
-	g.Printf("} else {\n")
-	g.Printf("lo = mid + 1\n")
-	g.Printf("}\n")
-	g.Printf("}\n")
-	g.Printf("return 0, false\n")
+		g.Printf("if %s == s {\n", fn.str)
+		g.Printf("return _%s_value_lookup[mid], true\n", typeName)
+		g.Printf("}\n")
+		g.Printf("if %s < s {\n", fn.str)
+		g.Printf("hi = mid\n")
+		g.Printf("} else {\n")
+		g.Printf("lo = mid + 1\n")
+		g.Printf("}\n")
+		g.Printf("}\n")
+		g.Printf("return %s, false\n", missValue(values))
+		g.Printf("}\n")
+	}
+}
+
+// buildLookupPerfect generates a lookup using a minimal perfect hash, so a
+// name is compared once at most. Falls back to the hash-switch if no
+// perfect hash is found.
+func (g *Generator) buildLookupPerfect(typeName string, values []Value) {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.repr
+	}
+	seeds, slots, ok := perfectHash(names)
+	if !ok {
+		log.Printf("no perfect hash found for %s, using a hash-switch", typeName)
+		g.buildLookup(typeName, values)
+		return
+	}
+	table := make([]Value, len(values))
+	for i, slot := range slots {
+		table[slot] = values[i]
+	}
+	g.Printf("\n")
+
+	//   const _<T>_name_perfect = "..."
+	//   var   _<T>_index_perfect = [...]uintN{...}
+	indexDecl, nameDecl := g.createIndexAndNameDecl(table, typeName, "_perfect")
+	g.Printf("const %s\n", nameDecl)
+	g.Printf("var %s\n", indexDecl)
+
+	g.Printf("var _%s_value_perfect = [...]%s{\n", typeName, typeName)
+	for _, v := range table {
+		g.Printf("%s,\n", v.original)
+	}
+	g.Printf("}\n\n")
+
+	g.Printf("var _%s_seed_perfect = [...]uint%d{", typeName, usize(int(slices.Max(seeds))))
+	for i, seed := range seeds {
+		if i > 0 {
+			g.Printf(", ")
+		}
+		g.Printf("%d", seed)
+	}
+	g.Printf("}\n\n")
+
+	if g.lookupBytes {
+		g.Printf("func _hash_%s[S string | []byte](seed uint32, s S) uint32 {\n", typeName)
+	} else {
+		g.Printf("func _hash_%s(seed uint32, s string) uint32 {\n", typeName)
+	}
+	g.Printf("h := 2166136261 ^ seed\n")
+	g.Printf("for i := 0; i < len(s); i++ {\n")
+	g.Printf("h ^= uint32(s[i])\n")
+	g.Printf("h *= 16777619\n")
 	g.Printf("}\n")
+	g.Printf("return h ^ h>>16\n")
+	g.Printf("}\n\n")
+
+	for i, fn := range g.lookupFuncs(typeName) {
+		if i > 0 {
+			g.Printf("\n")
+		}
+		g.Printf("func %s(name %s) (%s, bool) {\n", fn.name, fn.param, typeName)
+		if g.canonicalize() {
+			g.Printf("name = _canonical_%s(name)\n", typeName)
+		}
+		g.Printf("const n = %d\n", len(table))
+		g.Printf("seed := uint32(_%s_seed_perfect[_hash_%s(0, name)%%n])\n", typeName, typeName)
+		g.Printf("slot := _hash_%s(seed, name) %% n\n", typeName)
+		g.Printf("if %s != _%s_name_perfect[_%s_index_perfect[slot]:_%s_index_perfect[slot+1]] {\n", fn.str, typeName, typeName, typeName)
+		g.Printf("return %s, false\n", missValue(values))
+		g.Printf("}\n")
+		g.Printf("return _%s_value_perfect[slot], true\n", typeName)
+		g.Printf("}\n")
+	}
 }
 
 func (g *Generator) buildLookupMap(typeName string, values []Value) {
@@ -469,36 +1745,2184 @@ func (g *Generator) buildLookupMap(typeName string, values []Value) {
 	}
 	g.Printf("}\n")
 
-	funcName := strings.Replace(g.lookup, "{}", typeName, 1)
-	g.Printf("func %s(name string) (%s, bool) {\n", funcName, typeName)
-	g.Printf("value, ok := _%s_lookup[name]\n", typeName)
-	g.Printf("return value, ok\n")
+	for i, fn := range g.lookupFuncs(typeName) {
+		if i > 0 {
+			g.Printf("\n")
+		}
+		g.Printf("func %s(name %s) (%s, bool) {\n", fn.name, fn.param, typeName)
+		if g.canonicalize() {
+			g.Printf("name = _canonical_%s(name)\n", typeName)
+		}
+		g.Printf("value, ok := _%s_lookup[%s]\n", typeName, fn.str)
+		if miss := missValue(values); miss != "0" {
+			g.Printf("if !ok {\n")
+			g.Printf("return %s, false\n", miss)
+			g.Printf("}\n")
+		}
+		g.Printf("return value, ok\n")
+		g.Printf("}\n")
+	}
+}
+
+// lookupFunc describes a generated lookup function. The []byte variant
+// converts the name only where the compiler does not allocate.
+type lookupFunc struct {
+	name  string // Function name.
+	param string // Type of the parameter name.
+	str   string // The parameter as string.
+}
+
+// lookupFuncs returns the lookup functions sharing the tables of the lookup.
+// Canonicalization and splitting flags work on strings, so with them the
+// []byte variant is generated as wrapper by buildLookupBytes instead. With
+// -flags the tables resolve single names for buildLookupFlags.
+func (g *Generator) lookupFuncs(typeName string) []lookupFunc {
+	if g.flags {
+		return []lookupFunc{{"_lookupFlag_" + typeName, "string", "name"}}
+	}
+	fns := []lookupFunc{{strings.Replace(g.lookup, "{}", typeName, 1), "string", "name"}}
+	if g.lookupBytes && !g.canonicalize() {
+		fns = append(fns, lookupFunc{"Lookup" + typeName + "Bytes", "[]byte", "string(name)"})
+	}
+	return fns
+}
+
+// buildLookupFlags generates the lookup of combinations of flags, joined
+// by the separator, on top of the lookup of single names. The flags are
+// ORed together; _lookupFlags_T also returns the first unknown name for
+// error messages.
+func (g *Generator) buildLookupFlags(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func _lookupFlags_%s(name string) (%s, string, bool) {\n", typeName, typeName)
+	g.Printf("var i %s\n", typeName)
+	g.Printf("for token := range strings.SplitSeq(name, %q) {\n", g.flagSep)
+	g.Printf("token = strings.TrimSpace(token)\n")
+	g.Printf("flag, ok := _lookupFlag_%s(token)\n", typeName)
+	g.Printf("if !ok {\n")
+	g.Printf("return flag, token, false\n")
+	g.Printf("}\n")
+	g.Printf("i |= flag\n")
+	g.Printf("}\n")
+	g.Printf("return i, \"\", true\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func %s(name string) (%s, bool) {\n", lookupFunc, typeName)
+	g.Printf("i, _, ok := _lookupFlags_%s(name)\n", typeName)
+	g.Printf("return i, ok\n")
+	g.Printf("}\n")
+}
+
+// buildLookupBytes generates the []byte variant of a canonicalizing lookup.
+func (g *Generator) buildLookupBytes(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func Lookup%sBytes(name []byte) (%s, bool) {\n", typeName, typeName)
+	g.Printf("return %s(string(name))\n", lookupFunc)
+	g.Printf("}\n")
+}
+
+// missValue returns the value returned by the lookup for unknown names,
+// the constant marked by a "//morestringer:default" directive or zero.
+func missValue(values []Value) string {
+	miss := ""
+	for _, v := range values {
+		if v.isDefault && v.original != miss {
+			if miss != "" {
+				failf("both %s and %s are marked as default", miss, v.original)
+			}
+			miss = v.original
+		}
+	}
+	return cmp.Or(miss, "0")
+}
+
+// buildLookupWrapper generates the lookup in the shape requested by -lookup,
+// if it differs from the one used by the generated methods.
+func (g *Generator) buildLookupWrapper(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	sig := g.lookupWrapper
+	funcName := strings.Replace(sig.name, "{}", typeName, 1)
+	g.Printf("\n")
+	if sig.recv != "" {
+		g.Printf("func (%s) ", sig.recv)
+	} else {
+		g.Printf("func ")
+	}
+	g.Printf("%s(name %s) (%s, %s) {\n", funcName, sig.param, typeName, sig.result)
+	arg := "name"
+	if sig.param == "[]byte" {
+		arg = "string(name)"
+	}
+	switch {
+	case sig.result == "bool":
+		g.Printf("return %s(%s)\n", lookupFunc, arg)
+	case g.parse != "":
+		g.Printf("return Parse%s(%s)\n", typeName, arg)
+	default:
+		g.Printf("i, ok := %s(%s)\n", lookupFunc, arg)
+		g.Printf("if !ok {\n")
+		g.Printf("return 0, fmt.Errorf(\"invalid %s: %%q\", name)\n", typeName)
+		g.Printf("}\n")
+		g.Printf("return i, nil\n")
+	}
+	g.Printf("}\n")
+}
+
+// buildParse generates a parse function returning an error wrapping the
+// ErrInvalid sentinel, so callers can check for it with errors.Is.
+func (g *Generator) buildParse(values []Value, typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	parseFunc := "Parse" + typeName
+	if g.flags {
+		// Single names are parsed like other types, ParseT combines them.
+		lookupFunc = "_lookupFlag_" + typeName
+		parseFunc = "_parseFlag_" + typeName
+	}
+	g.buildSuggest(values, typeName)
+	g.Printf("\n")
+	g.Printf("// ErrInvalid%s is wrapped by the error of Parse%s for unknown names.\n", typeName, typeName)
+	g.Printf("var ErrInvalid%s = errors.New(\"invalid %s\")\n", typeName, typeName)
+	if g.flags {
+		g.Printf("\n")
+		g.Printf("// Parse%s returns the combination of the flags named in name, separated by %q.\n", typeName, g.flagSep)
+		g.Printf("// The error names the first unknown flag.\n")
+		g.Printf("func Parse%s(name string) (%s, error) {\n", typeName, typeName)
+		g.Printf("var i %s\n", typeName)
+		g.Printf("for token := range strings.SplitSeq(name, %q) {\n", g.flagSep)
+		g.Printf("flag, err := _parseFlag_%s(strings.TrimSpace(token))\n", typeName)
+		g.Printf("if err != nil {\n")
+		g.Printf("return 0, err\n")
+		g.Printf("}\n")
+		g.Printf("i |= flag\n")
+		g.Printf("}\n")
+		g.Printf("return i, nil\n")
+		g.Printf("}\n")
+	}
+	g.Printf("\n")
+	if !g.flags {
+		g.Printf("// Parse%s returns the %s with the given name.\n", typeName, typeName)
+	}
+	g.Printf("func %s(name string) (%s, error) {\n", parseFunc, typeName)
+	g.Printf("i, ok := %s(name)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	if g.parseNumbers != "" {
+		// Numbers are accepted as long as they are a defined value, or
+		// any value fitting the type with -parsenumbers=any. Base 0
+		// accepts prefixes like 0x and 0o.
+		valid := ""
+		if g.parseNumbers != "any" {
+			valid = fmt.Sprintf(" && _isValid_%s(%s(n))", typeName, typeName)
+		}
+		if values[0].signed {
+			g.Printf("if n, err := strconv.ParseInt(name, 0, 64); err == nil && int64(%s(n)) == n%s {\n", typeName, valid)
+		} else {
+			g.Printf("if n, err := strconv.ParseUint(name, 0, 64); err == nil && uint64(%s(n)) == n%s {\n", typeName, valid)
+		}
+		g.Printf("return %s(n), nil\n", typeName)
+		g.Printf("}\n")
+	}
+	g.Printf("if s := _suggest_%s(name); s != \"\" {\n", typeName)
+	g.Printf("return 0, fmt.Errorf(\"%%w: %%q, did you mean %%q?\", ErrInvalid%s, name, s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return 0, fmt.Errorf(\"%%w: %%q\", ErrInvalid%s, name)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return i, nil\n")
+	g.Printf("}\n")
+	if g.parse == "must" {
+		g.Printf("\n")
+		g.Printf("// MustParse%s is like Parse%s but panics for unknown names.\n", typeName, typeName)
+		g.Printf("func MustParse%s(name string) %s {\n", typeName, typeName)
+		g.Printf("i, err := Parse%s(name)\n", typeName)
+		g.Printf("if err != nil {\n")
+		g.Printf("panic(err)\n")
+		g.Printf("}\n")
+		g.Printf("return i\n")
+		g.Printf("}\n")
+	}
+}
+
+// buildSlice generates helpers for lists of values, as used by
+// command-line flags and their usage, relying on the parse function of
+// buildParse.
+func (g *Generator) buildSlice(values []Value, typeName, order string) {
+	g.Printf("\n")
+	g.Printf("// Parse%sSlice parses each of the names, failing on the first unknown name.\n", typeName)
+	g.Printf("func Parse%sSlice(names []string) ([]%s, error) {\n", typeName, typeName)
+	g.Printf("values := make([]%s, len(names))\n", typeName)
+	g.Printf("for n, name := range names {\n")
+	g.Printf("i, err := Parse%s(name)\n", typeName)
+	g.Printf("if err != nil {\n")
+	g.Printf("return nil, err\n")
+	g.Printf("}\n")
+	g.Printf("values[n] = i\n")
+	g.Printf("}\n")
+	g.Printf("return values, nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// %sNames returns the names of all values of %s %s, joined by sep.\n", typeName, typeName, order)
+	g.Printf("func %sNames(sep string) string {\n", typeName)
+	g.Printf("return strings.Join([]string{")
+	for _, v := range values {
+		g.Printf("%q, ", v.repr)
+	}
+	g.Printf("}, sep)\n")
+	g.Printf("}\n")
+}
+
+// buildCount generates constants holding the number of distinct values.
+func (g *Generator) buildCount(runs [][]Value, typeName string) {
+	n := 0
+	for _, values := range runs {
+		n += len(values)
+	}
+	g.Printf("\n")
+	g.Printf("// _%sCount is the number of values of %s.\n", typeName, typeName)
+	g.Printf("const _%sCount = %d\n", typeName, n)
+	if g.count == "exported" {
+		g.Printf("\n")
+		g.Printf("// Num%s is the number of values of %s.\n", typeName, typeName)
+		g.Printf("const Num%s = _%sCount\n", typeName, typeName)
+	}
+}
+
+// buildMinMax generates functions returning the smallest and largest
+// value, the bounds of the first and last run.
+func (g *Generator) buildMinMax(runs [][]Value, typeName string) {
+	last := runs[len(runs)-1]
+	g.Printf("\n")
+	g.Printf("// %sMin returns the smallest value of %s.\n", typeName, typeName)
+	g.Printf("func %sMin() %s {\n", typeName, typeName)
+	g.Printf("return %s\n", runs[0][0].original)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// %sMax returns the largest value of %s.\n", typeName, typeName)
+	g.Printf("func %sMax() %s {\n", typeName, typeName)
+	g.Printf("return %s\n", last[len(last)-1].original)
+	g.Printf("}\n")
+}
+
+// buildValues generates functions listing all values and their names.
+// The names are sliced from the name constants of the String method.
+func (g *Generator) buildValues(runs [][]Value, typeName string) {
+	values, names := g.listing(runs, typeName)
+	g.Printf("\n")
+	g.Printf("// %sValues returns all values of %s %s.\n", typeName, typeName, g.order())
+	g.Printf("func %sValues() []%s {\n", typeName, typeName)
+	g.Printf("return []%s{", typeName)
+	for _, v := range values {
+		g.Printf("%s, ", v.original)
+	}
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// %sNames returns the names of all values of %s, in the order of %sValues.\n", typeName, typeName, typeName)
+	g.Printf("func %sNames() []string {\n", typeName)
+	g.Printf("return []string{\n")
+	for _, name := range names {
+		g.Printf("%s,\n", name)
+	}
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// nameExprs returns for each value of the runs in ascending order an
+// expression slicing its name out of the name constants declared by
+// buildOneRun, buildMultipleRuns and buildMap.
+func nameExprs(runs [][]Value, typeName string) []string {
+	var exprs []string
+	multiple := len(runs) > 1 && len(runs) <= 10
+	n := 0
+	for i, values := range runs {
+		name := fmt.Sprintf("_%s_name", typeName)
+		if multiple {
+			name = fmt.Sprintf("_%s_name_%d", typeName, i)
+			n = 0
+			if len(values) == 1 {
+				exprs = append(exprs, name)
+				continue
+			}
+		}
+		for _, v := range values {
+			exprs = append(exprs, fmt.Sprintf("%s[%d:%d]", name, n, n+len(v.repr)))
+			n += len(v.repr)
+		}
+	}
+	return exprs
+}
+
+// listing returns the values of the runs and the expressions of their
+// names, given by nameExprs or quoted with -flags, in ascending order or,
+// with -declorder, in the order of their declaration.
+func (g *Generator) listing(runs [][]Value, typeName string) ([]Value, []string) {
+	values := slices.Concat(runs...)
+	names := nameExprs(runs, typeName)
+	if g.flags {
+		// The name constants of -flags hold the single bits only.
+		for i, v := range values {
+			names[i] = fmt.Sprintf("%q", v.repr)
+		}
+	}
+	if g.declOrder {
+		order := make([]int, len(values))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(values[a].pos, values[b].pos)
+		})
+		sorted := make([]Value, len(values))
+		sortedNames := make([]string, len(names))
+		for i, j := range order {
+			sorted[i], sortedNames[i] = values[j], names[j]
+		}
+		values, names = sorted, sortedNames
+	}
+	return values, names
+}
+
+// order describes the order of the values returned by listing, for the
+// doc comments of the generated functions.
+func (g *Generator) order() string {
+	if g.declOrder {
+		return "in the order of their declaration"
+	}
+	return "in ascending order"
+}
+
+// buildI18n generates the StringIn method, looking up the names of the
+// values in the catalogs embedded as a map by locale.
+func (g *Generator) buildI18n(runs [][]Value, typeName string) {
+	known := make(map[string]bool)
+	for _, values := range runs {
+		for _, v := range values {
+			known[v.repr] = true
+		}
+	}
+	g.Printf("\n")
+	g.Printf("var _%s_i18n = map[string]map[%s]string{\n", typeName, typeName)
+	for _, locale := range locales(g.catalogs) {
+		names, ok := g.catalogs[locale][typeName]
+		if !ok {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(names)) {
+			if !known[name] {
+				failf("catalog %s: %s has no value named %q", locale, typeName, name)
+			}
+		}
+		g.Printf("%q: {\n", locale)
+		for _, values := range runs {
+			for _, v := range values {
+				if name, ok := names[v.repr]; ok {
+					g.Printf("%s: %q,\n", v.original, name)
+				}
+			}
+		}
+		g.Printf("},\n")
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// StringIn returns the name of i in the locale lang, such as \"de\" or \"pt-BR\".\n")
+	g.Printf("// Locales without a name of i fall back to their base language and then to String.\n")
+	g.Printf("func (i %s) StringIn(lang string) string {\n", typeName)
+	g.Printf("for {\n")
+	g.Printf("if name, ok := _%s_i18n[lang][i]; ok {\n", typeName)
+	g.Printf("return name\n")
+	g.Printf("}\n")
+	g.Printf("n := strings.LastIndexAny(lang, \"-_\")\n")
+	g.Printf("if n < 0 {\n")
+	g.Printf("return i.String()\n")
+	g.Printf("}\n")
+	g.Printf("lang = lang[:n]\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildMessage generates MessageKey and TSetMessages, keying the names
+// as "T.Name" for golang.org/x/text/message. The names are escaped, as
+// messages are format strings.
+func (g *Generator) buildMessage(runs [][]Value, typeName string) {
+	var list strings.Builder
+	for _, values := range runs {
+		for _, v := range values {
+			fmt.Fprintf(&list, "%s, ", v.original)
+		}
+	}
+	name := "i.String()"
+	if g.catalogs != nil {
+		name = "i.StringIn(tag.String())"
+	}
+	g.Printf("\n")
+	g.Printf("// MessageKey returns the key of i for golang.org/x/text/message, as in\n")
+	g.Printf("// p.Sprintf(i.MessageKey()), falling back to String without a translation.\n")
+	g.Printf("func (i %s) MessageKey() message.Reference {\n", typeName)
+	g.Printf("return message.Key(%q+i.String(), strings.ReplaceAll(i.String(), \"%%\", \"%%%%\"))\n", typeName+".")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// %sSetMessages sets the names of all values of %s in the catalog for tag,\n", typeName, typeName)
+	g.Printf("// under the keys returned by MessageKey.\n")
+	g.Printf("func %sSetMessages(b *catalog.Builder, tag language.Tag) error {\n", typeName)
+	g.Printf("for _, i := range [...]%s{%s} {\n", typeName, list.String())
+	g.Printf("if err := b.SetString(tag, %q+i.String(), strings.ReplaceAll(%s, \"%%\", \"%%%%\")); err != nil {\n", typeName+".", name)
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildLabel generates the Label method, looking up the names given by
+// label directives by the language tag and its parents.
+func (g *Generator) buildLabel(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("var _%s_labels = map[%s]map[string]string{\n", typeName, typeName)
+	for _, values := range runs {
+		for _, v := range values {
+			if len(v.labels) == 0 {
+				continue
+			}
+			g.Printf("%s: {", v.original)
+			for _, tag := range slices.Sorted(maps.Keys(v.labels)) {
+				g.Printf("%q: %q, ", tag, v.labels[tag])
+			}
+			g.Printf("},\n")
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Label returns the name of i in the language tag, as declared by a\n")
+	g.Printf("// \"//morestringer:label\" directive. Tags without a name of i fall back\n")
+	g.Printf("// to their parents, as de-CH to de, and then to String.\n")
+	g.Printf("func (i %s) Label(tag language.Tag) string {\n", typeName)
+	g.Printf("labels := _%s_labels[i]\n", typeName)
+	g.Printf("for {\n")
+	g.Printf("if label, ok := labels[tag.String()]; ok {\n")
+	g.Printf("return label\n")
+	g.Printf("}\n")
+	g.Printf("if tag.IsRoot() {\n")
+	g.Printf("return i.String()\n")
+	g.Printf("}\n")
+	g.Printf("tag = tag.Parent()\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildTemplateFuncs generates TTemplateFuncs, exposing the parse,
+// listing and validity helpers to templates under the names of the
+// generated functions.
+func (g *Generator) buildTemplateFuncs(typeName string) {
+	g.Printf("\n")
+	g.Printf("// %sTemplateFuncs returns Parse%s, %sValues, %sNames and IsValid%s\n", typeName, typeName, typeName, typeName, typeName)
+	g.Printf("// as functions of text/template and html/template, as in {{range %sNames}}.\n", typeName)
+	g.Printf("func %sTemplateFuncs() template.FuncMap {\n", typeName)
+	g.Printf("return template.FuncMap{\n")
+	g.Printf("\"Parse%s\": Parse%s,\n", typeName, typeName)
+	g.Printf("\"%sValues\": %sValues,\n", typeName, typeName)
+	g.Printf("\"%sNames\": %sNames,\n", typeName, typeName)
+	g.Printf("\"IsValid%s\": _isValid_%s,\n", typeName, typeName)
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// usageWidth is the length after which buildUsage wraps the list of names.
+const usageWidth = 72
+
+// buildUsage generates TUsage, listing the names of all values as
+// "one of: A, B, C" for usage strings and error messages. Long lists are
+// wrapped into lines of at most usageWidth bytes where possible.
+func (g *Generator) buildUsage(runs [][]Value, typeName string) {
+	var b strings.Builder
+	b.WriteString("one of:")
+	line := b.Len()
+	values, _ := g.listing(runs, typeName)
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(",")
+			line++
+		}
+		if line > 0 && line+1+len(v.repr) > usageWidth {
+			b.WriteString("\n")
+			line = 0
+		} else {
+			b.WriteString(" ")
+			line++
+		}
+		b.WriteString(v.repr)
+		line += len(v.repr)
+	}
+	g.Printf("\n")
+	g.Printf("// %sUsage returns the names of all values of %s as \"one of: A, B, C\",\n", typeName, typeName)
+	g.Printf("// wrapped into lines for usage strings of flags and error messages.\n")
+	g.Printf("func %sUsage() string {\n", typeName)
+	g.Printf("return %q\n", b.String())
+	g.Printf("}\n")
+}
+
+// buildOptions generates TOptions, pairing every value with its name
+// for rendering choices such as HTML select elements.
+func (g *Generator) buildOptions(runs [][]Value, typeName string) {
+	values, names := g.listing(runs, typeName)
+	g.Printf("\n")
+	g.Printf("// %sOptions returns all values of %s %s, each with its name\n", typeName, typeName, g.order())
+	g.Printf("// as label, for rendering choices such as the options of an HTML select element.\n")
+	g.Printf("func %sOptions() []struct {\n", typeName)
+	g.Printf("Value %s\n", typeName)
+	g.Printf("Label string\n")
+	g.Printf("} {\n")
+	g.Printf("return []struct {\n")
+	g.Printf("Value %s\n", typeName)
+	g.Printf("Label string\n")
+	g.Printf("}{\n")
+	for i, v := range values {
+		g.Printf("{%s, %s},\n", v.original, names[i])
+	}
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildRegistry generates the registration of the lookup of the type in
+// the registry of LookupEnum, declared in the file written by writeEnums.
+func (g *Generator) buildRegistry(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func init() {\n")
+	g.Printf("_enumLookups[%q] = func(name string) (any, bool) {\n", typeName)
+	g.Printf("if i, ok := %s(name); ok {\n", lookupFunc)
+	g.Printf("return i, true\n")
+	g.Printf("}\n")
+	g.Printf("return nil, false\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildIter generates iterators over all values, ranging over an array
+// so that iterating does not allocate.
+func (g *Generator) buildIter(runs [][]Value, typeName string) {
+	var list strings.Builder
+	values, _ := g.listing(runs, typeName)
+	for _, v := range values {
+		fmt.Fprintf(&list, "%s, ", v.original)
+	}
+	g.Printf("\n")
+	g.Printf("// %sAll returns an iterator over all values of %s %s.\n", typeName, typeName, g.order())
+	g.Printf("func %sAll() iter.Seq[%s] {\n", typeName, typeName)
+	g.Printf("return func(yield func(%s) bool) {\n", typeName)
+	g.Printf("for _, i := range [...]%s{%s} {\n", typeName, list.String())
+	g.Printf("if !yield(i) {\n")
+	g.Printf("return\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	if g.flags {
+		g.Printf("\n")
+		flags := flagValues(runs)
+		slices.SortStableFunc(flags, func(a, b Value) int {
+			return cmp.Compare(a.pos, b.pos)
+		})
+		g.Printf("// Flags returns an iterator over the flags set in i in the order of their\n")
+		g.Printf("// declaration, followed by the bits without a name in ascending order.\n")
+		g.Printf("func (i %s) Flags() iter.Seq[%s] {\n", typeName, typeName)
+		g.Printf("return func(yield func(%s) bool) {\n", typeName)
+		if len(flags) > 0 {
+			g.Printf("for _, bit := range [...]%s{", typeName)
+			for i, v := range flags {
+				if i > 0 {
+					g.Printf(", ")
+				}
+				g.Printf("%s", v.original)
+			}
+			g.Printf("} {\n")
+			g.Printf("if i&bit == 0 {\n")
+			g.Printf("continue\n")
+			g.Printf("}\n")
+			g.Printf("if !yield(bit) {\n")
+			g.Printf("return\n")
+			g.Printf("}\n")
+			g.Printf("i &^= bit\n")
+			g.Printf("}\n")
+		}
+		g.Printf("for i != 0 {\n")
+		g.Printf("bit := i & -i // The lowest set bit.\n")
+		g.Printf("if !yield(bit) {\n")
+		g.Printf("return\n")
+		g.Printf("}\n")
+		g.Printf("i &^= bit\n")
+		g.Printf("}\n")
+		g.Printf("}\n")
+		g.Printf("}\n")
+	}
+	if g.iter != "all2" {
+		return
+	}
+	g.Printf("\n")
+	g.Printf("// %sAll2 returns an iterator over all values of %s and their names %s.\n", typeName, typeName, g.order())
+	g.Printf("func %sAll2() iter.Seq2[%s, string] {\n", typeName, typeName)
+	g.Printf("return func(yield func(%s, string) bool) {\n", typeName)
+	g.Printf("for _, i := range [...]%s{%s} {\n", typeName, list.String())
+	g.Printf("if !yield(i, i.String()) {\n")
+	g.Printf("return\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildNext generates methods stepping to the adjacent defined value,
+// skipping the holes between runs.
+func (g *Generator) buildNext(runs [][]Value, typeName string) {
+	first, last := &runs[0][0], &runs[len(runs)-1][len(runs[len(runs)-1])-1]
+	end, start := last, first
+	if g.next == "wrap" {
+		end, start = first, last
+	}
+
+	g.Printf("\n")
+	g.Printf("// Next returns the smallest value of %s greater than i,\n", typeName)
+	if g.next == "wrap" {
+		g.Printf("// or the smallest value if there is none.\n")
+	} else {
+		g.Printf("// or the largest value if there is none.\n")
+	}
+	g.Printf("func (i %s) Next() %s {\n", typeName, typeName)
+	g.Printf("switch {\n")
+	for n, values := range runs {
+		lo, hi := &values[0], &values[len(values)-1]
+		if n > 0 || lo.value != 0 || lo.signed {
+			// For an unsigned lower bound of 0, "i < 0" would be always false.
+			g.Printf("case i < %s:\n", lo)
+			g.Printf("return %s\n", lo.original)
+		}
+		if len(values) > 1 {
+			g.Printf("case i < %s:\n", hi)
+			g.Printf("return i + 1\n")
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("return %s\n", end.original)
+	g.Printf("}\n")
+
+	g.Printf("\n")
+	g.Printf("// Prev returns the largest value of %s less than i,\n", typeName)
+	if g.next == "wrap" {
+		g.Printf("// or the largest value if there is none.\n")
+	} else {
+		g.Printf("// or the smallest value if there is none.\n")
+	}
+	g.Printf("func (i %s) Prev() %s {\n", typeName, typeName)
+	g.Printf("switch {\n")
+	for n := len(runs) - 1; n >= 0; n-- {
+		values := runs[n]
+		lo, hi := &values[0], &values[len(values)-1]
+		g.Printf("case i > %s:\n", hi)
+		g.Printf("return %s\n", hi.original)
+		if len(values) > 1 {
+			g.Printf("case i > %s:\n", lo)
+			g.Printf("return i - 1\n")
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("return %s\n", start.original)
+	g.Printf("}\n")
+}
+
+// buildClamp generates a method returning the nearest defined value,
+// saturating at the smallest and largest value.
+func (g *Generator) buildClamp(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// Clamp returns i if it is defined, and otherwise the nearest value of %s,\n", typeName)
+	g.Printf("// preferring the smaller one if two are equally near.\n")
+	g.Printf("func (i %s) Clamp() %s {\n", typeName, typeName)
+	g.Printf("switch {\n")
+	for n, values := range runs {
+		lo, hi := &values[0], &values[len(values)-1]
+		if n == 0 {
+			if lo.value != 0 || lo.signed {
+				// For an unsigned lower bound of 0, "i < 0" would be always false.
+				g.Printf("case i < %s:\n", lo)
+				g.Printf("return %s\n", lo.original)
+			}
+		} else {
+			// The hole to the previous run is split in the middle.
+			prev := &runs[n-1][len(runs[n-1])-1]
+			mid := prev.value + (lo.value-prev.value)/2
+			if lo.signed {
+				g.Printf("case i <= %d:\n", int64(mid))
+			} else {
+				g.Printf("case i <= %d:\n", mid)
+			}
+			g.Printf("return %s\n", prev.original)
+			g.Printf("case i < %s:\n", lo)
+			g.Printf("return %s\n", lo.original)
+		}
+		g.Printf("case i <= %s:\n", hi)
+		g.Printf("return i\n")
+	}
+	last := runs[len(runs)-1]
+	g.Printf("}\n")
+	g.Printf("return %s\n", last[len(last)-1].original)
+	g.Printf("}\n")
+}
+
+// predicateName returns the name of the predicate of the constant, after
+// the constant without the trimmed prefix if that is a valid name.
+func predicateName(v Value) string {
+	name := v.trimmed
+	if !token.IsIdentifier(name) {
+		name = v.original
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return "Is" + string(unicode.ToUpper(r)) + name[size:]
+}
+
+// takenPredicates returns the methods named like predicates which other
+// options generate, by the option generating them.
+func (g *Generator) takenPredicates() map[string]string {
+	taken := make(map[string]string)
+	if g.isValid {
+		taken["IsValid"] = "-isvalid"
+	}
+	if g.deprecated != "" {
+		taken["IsDeprecated"] = "-deprecated"
+	}
+	if g.flags {
+		taken["IsSubsetOf"] = "-flags"
+	}
+	return taken
+}
+
+// buildPredicates generates an IsName method for each constant, named
+// after the constant without the trimmed prefix if that is a valid name.
+func (g *Generator) buildPredicates(values []Value, typeName string) {
+	taken := g.takenPredicates()
+	seen := make(map[string]string, len(values))
+	for _, v := range values {
+		method := predicateName(v)
+		if opt, ok := taken[method]; ok {
+			failf("%s: the predicate %s of constant %s is also generated by %s", typeName, method, v.original, opt)
+		}
+		if other, ok := seen[method]; ok {
+			failf("%s: constants %s and %s both have the predicate %s", typeName, other, v.original, method)
+		}
+		seen[method] = v.original
+		g.Printf("\n")
+		g.Printf("// %s reports whether i is %s.\n", method, v.original)
+		g.Printf("func (i %s) %s() bool {\n", typeName, method)
+		g.Printf("return i == %s\n", v.original)
+		g.Printf("}\n")
+	}
+}
+
+// buildEnums generates the registration of the type in EnumTypes,
+// relying on the functions of buildParse and buildValues.
+func (g *Generator) buildEnums(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("func init() {\n")
+	g.Printf("EnumTypes[%q] = EnumType{\n", typeName)
+	g.Printf("Name: %q,\n", typeName)
+	g.Printf("Values: []any{")
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("%s, ", v.original)
+		}
+	}
+	g.Printf("},\n")
+	g.Printf("Names: %sNames(),\n", typeName)
+	g.Printf("Parse: func(name string) (any, error) {\n")
+	g.Printf("return Parse%s(name)\n", typeName)
+	g.Printf("},\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildRuntime generates the registration of the type with the runtime
+// package, relying on the parse function of buildParse.
+func (g *Generator) buildRuntime(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("func init() {\n")
+	g.Printf("enum.Register(reflect.TypeFor[%s](), []enum.Enum{", typeName)
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("%s, ", v.original)
+		}
+	}
+	g.Printf("}, func(name string) (enum.Enum, error) {\n")
+	g.Printf("return Parse%s(name)\n", typeName)
+	g.Printf("})\n")
+	g.Printf("}\n")
+}
+
+// buildRanges generates a predicate for each range declared by a
+// "//morestringer:range" directive on the type.
+func (g *Generator) buildRanges(values []Value, typeName string) {
+	// bound checks that a bound is a constant of the type or a number
+	// fitting it.
+	bound := func(r valueRange, b string) string {
+		if slices.ContainsFunc(values, func(v Value) bool { return v.original == b }) {
+			return b
+		}
+		var err error
+		if values[0].signed {
+			_, err = strconv.ParseInt(b, 0, 64)
+		} else {
+			_, err = strconv.ParseUint(b, 0, 64)
+		}
+		if err != nil {
+			failf("%s: bound %q of range %s is neither a constant nor a number", typeName, b, r.name)
+		}
+		return b
+	}
+	taken := g.takenPredicates()
+	if g.predicates {
+		for _, v := range values {
+			taken[predicateName(v)] = "-predicates for constant " + v.original
+		}
+	}
+	for _, r := range g.ranges[typeName] {
+		if by, ok := taken["Is"+r.name]; ok {
+			failf("%s: the predicate Is%s of range %s is also generated by %s", typeName, r.name, r.name, by)
+		}
+		taken["Is"+r.name] = "range " + r.name
+		lo, hi := bound(r, r.lo), bound(r, r.hi)
+		g.Printf("\n")
+		g.Printf("// Is%s reports whether i is in the range %s..%s.\n", r.name, lo, hi)
+		g.Printf("func (i %s) Is%s() bool {\n", typeName, r.name)
+		if lo == "0" && !values[0].signed {
+			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
+			g.Printf("return i <= %s\n", hi)
+		} else {
+			g.Printf("return %s <= i && i <= %s\n", lo, hi)
+		}
+		g.Printf("}\n")
+	}
+}
+
+// wireNameOf returns the identifier of v without the trimmed prefix,
+// in the case requested by -wirename.
+func (g *Generator) wireNameOf(v Value) string {
+	switch g.wireName {
+	case "kebab":
+		return kebabCase(v.trimmed, g.acronyms)
+	case "screaming":
+		return screamingSnakeCase(v.trimmed, g.acronyms)
+	}
+	return snakeCase(v.trimmed, g.acronyms)
+}
+
+// buildWireName generates a method returning the identifier of a value
+// in the requested case, next to the display name returned by String.
+func (g *Generator) buildWireName(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// WireName returns the name of i for serialization, in %s case.\n", g.wireName)
+	g.Printf("func (i %s) WireName() string {\n", typeName)
+	g.Printf("switch i {\n")
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("case %s:\n", v.original)
+			g.Printf("return %q\n", g.wireNameOf(v))
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("return \"%s(\" + %s + \")\"\n", typeName, formatInt("i", runs[0][0].signed))
+	g.Printf("}\n")
+}
+
+// buildFingerprint generates a hash of the names and values, computed
+// with FNV-1a over "name:value\n" for each value in ascending order.
+func (g *Generator) buildFingerprint(runs [][]Value, typeName string) {
+	h := fnv.New64a()
+	for _, values := range runs {
+		for _, v := range values {
+			fmt.Fprintf(h, "%s:%s\n", v.repr, v.str)
+		}
+	}
+	g.Printf("\n")
+	g.Printf("// _%s_hash is the FNV-1a hash of the names and values of %s.\n", typeName, typeName)
+	g.Printf("const _%s_hash = %#016x\n", typeName, h.Sum64())
+	g.Printf("\n")
+	g.Printf("// %sFingerprint returns a hash of the names and values of %s, so that\n", typeName, typeName)
+	g.Printf("// programs can check that they agree on its definition.\n")
+	g.Printf("func %sFingerprint() uint64 {\n", typeName)
+	g.Printf("return _%s_hash\n", typeName)
+	g.Printf("}\n")
+}
+
+// buildOrdinal generates a mapping between the values and their dense
+// index, counting the values of the runs in ascending order.
+func (g *Generator) buildOrdinal(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// Ordinal returns the index of i among the values of %s in ascending order,\n", typeName)
+	g.Printf("// or -1 if i is not defined.\n")
+	g.Printf("func (i %s) Ordinal() int {\n", typeName)
+	g.Printf("switch {\n")
+	base := 0
+	for _, values := range runs {
+		lo, hi := &values[0], &values[len(values)-1]
+		switch {
+		case len(values) == 1:
+			g.Printf("case i == %s:\n", lo)
+			g.Printf("return %d\n", base)
+			base++
+			continue
+		case lo.value == 0 && !lo.signed:
+			// For an unsigned lower bound of 0, "0 <= i" would be redundant.
+			g.Printf("case i <= %s:\n", hi)
+		default:
+			g.Printf("case %s <= i && i <= %s:\n", lo, hi)
+		}
+		switch {
+		case lo.signed:
+			// Convert first, so that the difference cannot overflow.
+			g.Printf("return %s\n", addInt("int(i)", base-int(int64(lo.value))))
+		case lo.value == 0:
+			g.Printf("return %s\n", addInt("int(i)", base))
+		default:
+			g.Printf("return %s\n", addInt(fmt.Sprintf("int(i - %s)", lo), base))
+		}
+		base += len(values)
+	}
+	g.Printf("}\n")
+	g.Printf("return -1\n")
+	g.Printf("}\n")
+
+	g.Printf("\n")
+	g.Printf("// %sFromOrdinal returns the value of %s with the given index, as returned by Ordinal.\n", typeName, typeName)
+	g.Printf("func %sFromOrdinal(n int) (%s, bool) {\n", typeName, typeName)
+	g.Printf("switch {\n")
+	base = 0
+	for _, values := range runs {
+		lo := &values[0]
+		if len(values) == 1 {
+			g.Printf("case n == %d:\n", base)
+			g.Printf("return %s, true\n", lo.original)
+			base++
+			continue
+		}
+		g.Printf("case %d <= n && n < %d:\n", base, base+len(values))
+		if lo.signed {
+			g.Printf("return %s(%s), true\n", typeName, addInt("n", int(int64(lo.value))-base))
+		} else {
+			i := fmt.Sprintf("%s(%s)", typeName, addInt("n", -base))
+			if lo.value != 0 {
+				i = fmt.Sprintf("%s + %s", i, lo)
+			}
+			g.Printf("return %s, true\n", i)
+		}
+		base += len(values)
+	}
+	g.Printf("}\n")
+	g.Printf("return 0, false\n")
+	g.Printf("}\n")
+}
+
+// addInt returns the expression x + n, omitting a zero n.
+func addInt(x string, n int) string {
+	switch {
+	case n > 0:
+		return fmt.Sprintf("%s + %d", x, n)
+	case n < 0:
+		return fmt.Sprintf("%s - %d", x, -n)
+	}
+	return x
+}
+
+// buildDeprecated generates methods reporting deprecated values. A value
+// is deprecated if all constants sharing it are, so that deprecating an
+// old name of a value does not retire the value itself.
+func (g *Generator) buildDeprecated(values []Value, typeName string) {
+	var deprecated []Value
+	for _, v := range values {
+		if !v.deprecated || slices.ContainsFunc(deprecated, func(d Value) bool { return d.value == v.value }) {
+			continue
+		}
+		if !slices.ContainsFunc(values, func(o Value) bool { return o.value == v.value && !o.deprecated }) {
+			deprecated = append(deprecated, v)
+		}
+	}
+
+	g.Printf("\n")
+	g.Printf("// IsDeprecated reports whether i is documented as deprecated.\n")
+	g.Printf("func (i %s) IsDeprecated() bool {\n", typeName)
+	if len(deprecated) > 0 {
+		g.Printf("switch i {\n")
+		g.Printf("case ")
+		for n, v := range deprecated {
+			if n > 0 {
+				g.Printf(", ")
+			}
+			g.Printf("%s", v.original)
+		}
+		g.Printf(":\n")
+		g.Printf("return true\n")
+		g.Printf("}\n")
+	}
+	g.Printf("return false\n")
+	g.Printf("}\n")
+	if g.deprecated != "message" {
+		return
+	}
+	g.Printf("\n")
+	g.Printf("// DeprecatedMessage returns the deprecation notice of i, or \"\" if i is not deprecated.\n")
+	g.Printf("func (i %s) DeprecatedMessage() string {\n", typeName)
+	if len(deprecated) > 0 {
+		g.Printf("switch i {\n")
+		for _, v := range deprecated {
+			g.Printf("case %s:\n", v.original)
+			g.Printf("return %q\n", v.deprecation)
+		}
+		g.Printf("}\n")
+	}
+	g.Printf("return \"\"\n")
+	g.Printf("}\n")
+}
+
+// buildCategory generates a method returning the category of a value,
+// grouping the cases by category in the order of their first value.
+func (g *Generator) buildCategory(runs [][]Value, typeName string) {
+	var categories []string
+	byCategory := make(map[string][]string)
+	for _, values := range runs {
+		for _, v := range values {
+			if v.category == "" {
+				continue
+			}
+			if _, ok := byCategory[v.category]; !ok {
+				categories = append(categories, v.category)
+			}
+			byCategory[v.category] = append(byCategory[v.category], v.original)
+		}
+	}
+
+	g.Printf("\n")
+	g.Printf("// Category returns the first line of the doc comment of the const block declaring i.\n")
+	g.Printf("func (i %s) Category() string {\n", typeName)
+	if len(categories) > 0 {
+		g.Printf("switch i {\n")
+		for _, category := range categories {
+			g.Printf("case %s:\n", strings.Join(byCategory[category], ", "))
+			g.Printf("return %q\n", category)
+		}
+		g.Printf("}\n")
+	}
+	g.Printf("return \"\"\n")
+	g.Printf("}\n")
+}
+
+// buildMeta generates a method returning the metadata of a value.
+func (g *Generator) buildMeta(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// Meta returns the metadata of i with the given key, as declared by\n")
+	g.Printf("// a \"//morestringer:meta key=value\" directive, or \"\" if there is none.\n")
+	g.Printf("func (i %s) Meta(key string) string {\n", typeName)
+	var cases []Value
+	for _, values := range runs {
+		for _, v := range values {
+			if len(v.meta) > 0 {
+				cases = append(cases, v)
+			}
+		}
+	}
+	if len(cases) > 0 {
+		g.Printf("switch i {\n")
+		for _, v := range cases {
+			g.Printf("case %s:\n", v.original)
+			g.Printf("switch key {\n")
+			for _, key := range slices.Sorted(maps.Keys(v.meta)) {
+				g.Printf("case %q:\n", key)
+				g.Printf("return %q\n", v.meta[key])
+			}
+			g.Printf("}\n")
+		}
+		g.Printf("}\n")
+	}
+	g.Printf("return \"\"\n")
+	g.Printf("}\n")
+}
+
+// buildRandom generates a function returning random values for tests,
+// also implementing testing/quick.Generator.
+func (g *Generator) buildRandom(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// Random%s returns a random value of %s, each value being equally likely.\n", typeName, typeName)
+	g.Printf("func Random%s(r *rand.Rand) %s {\n", typeName, typeName)
+	g.Printf("values := [...]%s{", typeName)
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("%s, ", v.original)
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("return values[r.Intn(len(values))]\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Generate implements testing/quick.Generator, generating random values of %s.\n", typeName)
+	g.Printf("func (%s) Generate(r *rand.Rand, size int) reflect.Value {\n", typeName)
+	g.Printf("return reflect.ValueOf(Random%s(r))\n", typeName)
+	g.Printf("}\n")
+}
+
+// maxSetWords limits the size of the array backing a set generated by
+// buildSet, as sparse values would make sets needlessly large.
+const maxSetWords = 64
+
+// buildSet generates a bitset of values, indexing the bits by the offset
+// of a value to the smallest one. If the offsets fit, the set is a
+// uint64, otherwise an array of them.
+func (g *Generator) buildSet(runs [][]Value, typeName string) {
+	last := runs[len(runs)-1]
+	lo, hi := &runs[0][0], &last[len(last)-1]
+	words := (hi.value-lo.value)/64 + 1
+	if words > maxSetWords {
+		failf("%s: values span more than %d bits, too many for -set", typeName, maxSetWords*64)
+	}
+	setName := typeName + "Set"
+	// The offset is computed as int64 for signed types, so that
+	// it does not overflow.
+	offset := fmt.Sprintf("uint(i - %s)", lo)
+	value := fmt.Sprintf("%s(n) + %s", typeName, lo)
+	switch {
+	case lo.value == 0:
+		offset, value = "uint(i)", typeName+"(n)"
+	case lo.signed:
+		offset = "uint(" + addInt("int64(i)", -int(int64(lo.value))) + ")"
+		value = typeName + "(" + addInt("int64(n)", int(int64(lo.value))) + ")"
+	}
+
+	g.Printf("\n")
+	g.Printf("// %s is a set of %s values.\n", setName, typeName)
+	if words == 1 {
+		g.Printf("type %s uint64\n", setName)
+	} else {
+		g.Printf("type %s [%d]uint64\n", setName, words)
+	}
+	g.Printf("\n")
+	g.Printf("// New%s returns a set holding the given values.\n", setName)
+	g.Printf("func New%s(values ...%s) %s {\n", setName, typeName, setName)
+	g.Printf("var s %s\n", setName)
+	g.Printf("for _, i := range values {\n")
+	g.Printf("s.Add(i)\n")
+	g.Printf("}\n")
+	g.Printf("return s\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func _%s_bit(i %s) (uint, bool) {\n", setName, typeName)
+	if lo.value == 0 && !lo.signed {
+		// For an unsigned lower bound of 0, "i < 0" would be always false.
+		g.Printf("if i > %s {\n", hi)
+	} else {
+		g.Printf("if i < %s || i > %s {\n", lo, hi)
+	}
+	g.Printf("return 0, false\n")
+	g.Printf("}\n")
+	g.Printf("return %s, true\n", offset)
+	g.Printf("}\n")
+
+	word, bit := "*s", "1 << n"
+	if words > 1 {
+		word, bit = "s[n/64]", "1 << (n % 64)"
+	}
+	g.Printf("\n")
+	g.Printf("// Add adds i to the set. Values outside the range of %s are ignored.\n", typeName)
+	g.Printf("func (s *%s) Add(i %s) {\n", setName, typeName)
+	g.Printf("if n, ok := _%s_bit(i); ok {\n", setName)
+	g.Printf("%s |= %s\n", word, bit)
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Remove removes i from the set.\n")
+	g.Printf("func (s *%s) Remove(i %s) {\n", setName, typeName)
+	g.Printf("if n, ok := _%s_bit(i); ok {\n", setName)
+	g.Printf("%s &^= %s\n", word, bit)
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Contains reports whether i is in the set.\n")
+	g.Printf("func (s %s) Contains(i %s) bool {\n", setName, typeName)
+	g.Printf("n, ok := _%s_bit(i)\n", setName)
+	g.Printf("return ok && %s&(%s) != 0\n", strings.TrimPrefix(word, "*"), bit)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Len returns the number of values in the set.\n")
+	g.Printf("func (s %s) Len() int {\n", setName)
+	if words == 1 {
+		g.Printf("return bits.OnesCount64(uint64(s))\n")
+	} else {
+		g.Printf("n := 0\n")
+		g.Printf("for _, w := range s {\n")
+		g.Printf("n += bits.OnesCount64(w)\n")
+		g.Printf("}\n")
+		g.Printf("return n\n")
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Iterate calls yield for the values in the set in ascending order, until\n")
+	g.Printf("// yield returns false. It can be ranged over as in \"for i := range s.Iterate\".\n")
+	g.Printf("func (s %s) Iterate(yield func(%s) bool) {\n", setName, typeName)
+	if words == 1 {
+		g.Printf("for w := uint64(s); w != 0; w &= w - 1 {\n")
+		g.Printf("n := bits.TrailingZeros64(w)\n")
+	} else {
+		g.Printf("for k, w := range s {\n")
+		g.Printf("for ; w != 0; w &= w - 1 {\n")
+		g.Printf("n := k*64 + bits.TrailingZeros64(w)\n")
+	}
+	g.Printf("if !yield(%s) {\n", value)
+	g.Printf("return\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	if words > 1 {
+		g.Printf("}\n")
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// String returns the names of the values in the set, as in \"{A, B}\".\n")
+	g.Printf("func (s %s) String() string {\n", setName)
+	g.Printf("var b strings.Builder\n")
+	g.Printf("b.WriteByte('{')\n")
+	g.Printf("for i := range s.Iterate {\n")
+	g.Printf("if b.Len() > 1 {\n")
+	g.Printf("b.WriteString(\", \")\n")
+	g.Printf("}\n")
+	g.Printf("b.WriteString(i.String())\n")
+	g.Printf("}\n")
+	g.Printf("b.WriteByte('}')\n")
+	g.Printf("return b.String()\n")
+	g.Printf("}\n")
+}
+
+// buildEnumMap generates a map type backed by arrays indexed by the
+// ordinal of the values, relying on the functions of buildOrdinal.
+func (g *Generator) buildEnumMap(runs [][]Value, typeName string) {
+	n := 0
+	for _, values := range runs {
+		n += len(values)
+	}
+	mapName := typeName + "Map"
+	g.Printf("\n")
+	g.Printf("// %s maps values of %s to values of V without hashing, storing\n", mapName, typeName)
+	g.Printf("// them in an array indexed by the ordinal of the key.\n")
+	g.Printf("type %s[V any] struct {\n", mapName)
+	g.Printf("values [%d]V\n", n)
+	g.Printf("set [%d]bool\n", n)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Get returns the value stored for i and whether there is one.\n")
+	g.Printf("func (m *%s[V]) Get(i %s) (V, bool) {\n", mapName, typeName)
+	g.Printf("if n := i.Ordinal(); n >= 0 && m.set[n] {\n")
+	g.Printf("return m.values[n], true\n")
+	g.Printf("}\n")
+	g.Printf("var zero V\n")
+	g.Printf("return zero, false\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Set stores v for i. Values of %s which are not defined are ignored.\n", typeName)
+	g.Printf("func (m *%s[V]) Set(i %s, v V) {\n", mapName, typeName)
+	g.Printf("if n := i.Ordinal(); n >= 0 {\n")
+	g.Printf("m.values[n], m.set[n] = v, true\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Delete removes the value stored for i.\n")
+	g.Printf("func (m *%s[V]) Delete(i %s) {\n", mapName, typeName)
+	g.Printf("if n := i.Ordinal(); n >= 0 {\n")
+	g.Printf("var zero V\n")
+	g.Printf("m.values[n], m.set[n] = zero, false\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Len returns the number of values stored.\n")
+	g.Printf("func (m *%s[V]) Len() int {\n", mapName)
+	g.Printf("n := 0\n")
+	g.Printf("for _, ok := range m.set {\n")
+	g.Printf("if ok {\n")
+	g.Printf("n++\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("return n\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Range calls yield for the stored keys in ascending order and their values,\n")
+	g.Printf("// until yield returns false. It can be ranged over as in \"for i, v := range m.Range\".\n")
+	g.Printf("func (m *%s[V]) Range(yield func(%s, V) bool) {\n", mapName, typeName)
+	g.Printf("for n, ok := range m.set {\n")
+	g.Printf("if !ok {\n")
+	g.Printf("continue\n")
+	g.Printf("}\n")
+	g.Printf("i, _ := %sFromOrdinal(n)\n", typeName)
+	g.Printf("if !yield(i, m.values[n]) {\n")
+	g.Printf("return\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildValidate generates a Validate method returning a typed error for
+// values which are not defined.
+func (g *Generator) buildValidate(typeName string, signed bool) {
+	errName := "Invalid" + typeName + "Error"
+	g.Printf("\n")
+	g.Printf("// %s is returned by Validate for values which are not defined.\n", errName)
+	g.Printf("type %s struct {\n", errName)
+	g.Printf("Value %s\n", typeName)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (e *%s) Error() string {\n", errName)
+	g.Printf("return \"invalid %s: \" + %s\n", typeName, formatInt("e.Value", signed))
+	g.Printf("}\n")
+	if g.parse != "" {
+		g.Printf("\n")
+		g.Printf("// Unwrap returns ErrInvalid%s, so that errors.Is matches it.\n", typeName)
+		g.Printf("func (e *%s) Unwrap() error {\n", errName)
+		g.Printf("return ErrInvalid%s\n", typeName)
+		g.Printf("}\n")
+	}
+	g.Printf("\n")
+	g.Printf("// Validate returns an *%s if i is not a defined value of %s.\n", errName, typeName)
+	g.Printf("func (i %s) Validate() error {\n", typeName)
+	g.Printf("if !_isValid_%s(i) {\n", typeName)
+	g.Printf("return &%s{Value: i}\n", errName)
+	g.Printf("}\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildSuggest generates a function returning the name closest to a
+// mistyped one by their edit distance, ignoring case.
+func (g *Generator) buildSuggest(values []Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("func _suggest_%s(name string) string {\n", typeName)
+	g.Printf("a := []rune(strings.ToLower(name))\n")
+	g.Printf("best, bestDist := \"\", 0\n")
+	g.Printf("for _, n := range [...]string{")
+	for _, v := range values {
+		g.Printf("%q, ", v.repr)
+		for _, alias := range v.aliases {
+			g.Printf("%q, ", alias)
+		}
+	}
+	g.Printf("} {\n")
+	g.Printf("b := []rune(strings.ToLower(n))\n")
+	g.Printf("// Levenshtein distance, keeping a single row.\n")
+	g.Printf("row := make([]int, len(b)+1)\n")
+	g.Printf("for j := range row {\n")
+	g.Printf("row[j] = j\n")
+	g.Printf("}\n")
+	g.Printf("for i := range a {\n")
+	g.Printf("prev := row[0]\n")
+	g.Printf("row[0] = i + 1\n")
+	g.Printf("for j := range b {\n")
+	g.Printf("cost := 1\n")
+	g.Printf("if a[i] == b[j] {\n")
+	g.Printf("cost = 0\n")
+	g.Printf("}\n")
+	g.Printf("prev, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, prev+cost)\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("if d := row[len(b)]; d <= max(2, len(b)/3) && (best == \"\" || d < bestDist) {\n")
+	g.Printf("best, bestDist = n, d\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("return best\n")
+	g.Printf("}\n")
+}
+
+// buildUnknown generates the assignment of the value named by name to *i,
+// handling unknown names as selected by -unknown: errExpr is returned, the
+// zero value is kept, or the name is parsed as number.
+func (g *Generator) buildUnknown(typeName, name, errExpr string, signed bool) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	if g.unknown == "zero" {
+		g.Printf("*i, _ = %s(%s)\n", lookupFunc, name)
+		return
+	}
+	g.Printf("m, ok := %s(%s)\n", lookupFunc, name)
+	if g.unknown == "number" {
+		g.Printf("if !ok {\n")
+		if signed {
+			g.Printf("n, err := strconv.ParseInt(%s, 10, 64)\n", name)
+			g.Printf("m, ok = %s(n), err == nil && int64(%s(n)) == n\n", typeName, typeName)
+		} else {
+			g.Printf("n, err := strconv.ParseUint(%s, 10, 64)\n", name)
+			g.Printf("m, ok = %s(n), err == nil && uint64(%s(n)) == n\n", typeName, typeName)
+		}
+		g.Printf("}\n")
+	}
+	g.Printf("if !ok {\n")
+	g.Printf("return %s\n", errExpr)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+}
+
+func (g *Generator) buildJson(runs [][]Value, typeName string) {
+	signed := runs[0][0].signed
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	typeError := fmt.Sprintf("&json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(%s(0))}", typeName)
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalJSON() ([]byte, error) {\n", typeName)
+	switch {
+	case g.json == "number":
+		g.Printf("return json.Marshal(%s(i))\n", intType(signed))
+	case g.flags:
+		// An array of the names of the set flags, followed by the
+		// bits without a name as number.
+		g.Printf("flags := []any{}\n")
+		if len(flagValues(runs)) > 0 {
+			g.Printf("for n, bit := range _%s_bits {\n", typeName)
+			g.Printf("if i&bit != 0 {\n")
+			g.Printf("flags = append(flags, _%s_name[_%s_index[n]:_%s_index[n+1]])\n", typeName, typeName, typeName)
+			g.Printf("i &^= bit\n")
+			g.Printf("}\n")
+			g.Printf("}\n")
+		}
+		g.Printf("if i != 0 {\n")
+		g.Printf("flags = append(flags, %s(i))\n", intType(signed))
+		g.Printf("}\n")
+		g.Printf("return json.Marshal(flags)\n")
+	default:
+		g.Printf("return json.Marshal(i.String())\n")
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalJSON(b []byte) error {\n", typeName)
+	g.Printf("var value any\n")
+	// Numbers are decoded exactly, as a float64 does not hold all values.
+	g.Printf("dec := json.NewDecoder(bytes.NewReader(b))\n")
+	g.Printf("dec.UseNumber()\n")
+	g.Printf("if err := dec.Decode(&value); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("switch v := value.(type) {\n")
+	g.Printf("case string:\n")
+	g.buildUnknown(typeName, "v", typeError, signed)
+	if g.flags {
+		g.Printf("case []any:\n")
+		g.Printf("var m %s\n", typeName)
+		g.Printf("for _, flag := range v {\n")
+		g.Printf("switch flag := flag.(type) {\n")
+		g.Printf("case string:\n")
+		g.Printf("n, ok := %s(flag)\n", lookupFunc)
+		g.Printf("if !ok {\n")
+		g.Printf("return %s\n", typeError)
+		g.Printf("}\n")
+		g.Printf("m |= n\n")
+		g.Printf("case json.Number:\n")
+		g.Printf("n, err := %s\n", parseInt("string(flag)", signed))
+		g.Printf("if err != nil {\n")
+		g.Printf("return %s\n", typeError)
+		g.Printf("}\n")
+		g.Printf("m |= %s(n)\n", typeName)
+		g.Printf("default:\n")
+		g.Printf("return %s\n", typeError)
+		g.Printf("}\n")
+		g.Printf("}\n")
+		g.Printf("*i = m\n")
+	}
+	g.Printf("case json.Number:\n")
+	g.Printf("n, err := %s\n", parseInt("string(v)", signed))
+	g.Printf("if err != nil {\n")
+	g.Printf("return %s\n", typeError)
+	g.Printf("}\n")
+	if g.checkNumbers {
+		g.Printf("if %s(%s(n)) != n || !_isValid_%s(%s(n)) {\n", intType(signed), typeName, typeName, typeName)
+		g.Printf("return %s\n", typeError)
+		g.Printf("}\n")
+	}
+	g.Printf("*i = %s(n)\n", typeName)
+	g.Printf("default:\n")
+	g.Printf("return %s\n", typeError)
+	g.Printf("}\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildJsonV2(typeName string, signed bool) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalJSONTo(enc *jsontext.Encoder) error {\n", typeName)
+	if g.jsonv2 == "number" {
+		if signed {
+			g.Printf("return enc.WriteToken(jsontext.Int(int64(i)))\n")
+		} else {
+			g.Printf("return enc.WriteToken(jsontext.Uint(uint64(i)))\n")
+		}
+	} else {
+		g.Printf("return enc.WriteToken(jsontext.String(i.String()))\n")
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalJSONFrom(dec *jsontext.Decoder) error {\n", typeName)
+	g.Printf("tok, err := dec.ReadToken()\n")
+	g.Printf("if err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("switch tok.Kind() {\n")
+	g.Printf("case '\"':\n")
+	g.Printf("m, ok := %s(tok.String())\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", tok.String())\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("case '0':\n")
+	if signed {
+		g.Printf("n, err := tok.Int()\n")
+	} else {
+		g.Printf("n, err := tok.Uint()\n")
+	}
+	g.Printf("if err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	if g.checkNumbers {
+		g.Printf("if !_isValid_%s(%s(n)) {\n", typeName, typeName)
+		g.Printf("return fmt.Errorf(\"invalid %s: %%d\", n)\n", typeName)
+		g.Printf("}\n")
+	}
+	g.Printf("*i = %s(n)\n", typeName)
+	g.Printf("default:\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: unexpected %%s\", tok.Kind())\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildText(typeName string, signed bool) {
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalText() ([]byte, error) {\n", typeName)
+	g.Printf("return []byte(i.String()), nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i %s) AppendText(b []byte) ([]byte, error) {\n", typeName)
+	g.Printf("return append(b, i.String()...), nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalText(text []byte) error {\n", typeName)
+	if g.flags && g.unknown == "error" {
+		// Name the unknown flag rather than the whole text.
+		g.Printf("m, token, ok := _lookupFlags_%s(string(text))\n", typeName)
+		g.Printf("if !ok {\n")
+		g.Printf("return fmt.Errorf(\"invalid %s: %%q\", token)\n", typeName)
+		g.Printf("}\n")
+		g.Printf("*i = m\n")
+	} else {
+		g.buildUnknown(typeName, "string(text)", fmt.Sprintf("fmt.Errorf(\"invalid %s: %%q\", text)", typeName), signed)
+	}
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildSql generates the driver.Valuer and sql.Scanner methods, which
+// reject undefined values in both directions.
+func (g *Generator) buildSql(typeName string, signed bool) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	// Flags are defined when every bit set belongs to a constant.
+	valid := func(x string) string {
+		if g.flags {
+			return fmt.Sprintf("%s&^_%sAll == 0", x, typeName)
+		}
+		return fmt.Sprintf("_isValid_%s(%s)", typeName, x)
+	}
+	g.Printf("\n")
+	g.Printf("func (i %s) Value() (driver.Value, error) {\n", typeName)
+	g.Printf("if !(%s) {\n", valid("i"))
+	g.Printf("return nil, fmt.Errorf(\"invalid %s: %%d\", %s(i))\n", typeName, intType(signed))
+	g.Printf("}\n")
+	g.Printf("return i.String(), nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) Scan(src any) error {\n", typeName)
+	g.Printf("switch v := src.(type) {\n")
+	g.Printf("case string:\n")
+	g.Printf("m, ok := %s(v)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", v)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("case []byte:\n")
+	g.Printf("m, ok := %s(string(v))\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", v)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("case int64:\n")
+	g.Printf("if int64(%s(v)) != v || !(%s) {\n", typeName, valid(typeName+"(v)"))
+	g.Printf("return fmt.Errorf(\"invalid %s: %%d\", v)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = %s(v)\n", typeName)
+	g.Printf("default:\n")
+	g.Printf("return fmt.Errorf(\"cannot scan %%T into %s\", src)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildSqlNull generates a nullable wrapper of the type, like sql.NullString.
+// It relies on the methods generated by buildSql.
+func (g *Generator) buildSqlNull(typeName string) {
+	g.Printf("\n")
+	g.Printf("// Null%[1]s represents a %[1]s that may be null.\n", typeName)
+	g.Printf("type Null%s struct {\n", typeName)
+	g.Printf("%s %s\n", typeName, typeName)
+	g.Printf("Valid bool // Valid is true if %s is not NULL\n", typeName)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (n Null%s) Value() (driver.Value, error) {\n", typeName)
+	g.Printf("if !n.Valid {\n")
+	g.Printf("return nil, nil\n")
+	g.Printf("}\n")
+	g.Printf("return n.%s.Value()\n", typeName)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (n *Null%s) Scan(src any) error {\n", typeName)
+	g.Printf("if src == nil {\n")
+	g.Printf("n.%s, n.Valid = 0, false\n", typeName)
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+	g.Printf("if err := n.%s.Scan(src); err != nil {\n", typeName)
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("n.Valid = true\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (n Null%s) MarshalJSON() ([]byte, error) {\n", typeName)
+	g.Printf("if !n.Valid {\n")
+	g.Printf("return []byte(\"null\"), nil\n")
+	g.Printf("}\n")
+	g.Printf("return json.Marshal(n.%s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (n *Null%s) UnmarshalJSON(b []byte) error {\n", typeName)
+	g.Printf("if string(b) == \"null\" {\n")
+	g.Printf("n.%s, n.Valid = 0, false\n", typeName)
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+	g.Printf("if err := json.Unmarshal(b, &n.%s); err != nil {\n", typeName)
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("n.Valid = true\n")
+	g.Printf("return nil\n")
 	g.Printf("}\n")
 }
 
-func (g *Generator) buildJson(typeName string) {
+func (g *Generator) buildYaml(typeName string) {
 	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
-	g.Printf("func (i %s) MarshalJSON() ([]byte, error) {\n", typeName)
-	g.Printf("return json.Marshal(i.String())\n")
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalYAML() (any, error) {\n", typeName)
+	g.Printf("return i.String(), nil\n")
 	g.Printf("}\n")
 	g.Printf("\n")
-	g.Printf("func (i *%s) UnmarshalJSON(b []byte) error {\n", typeName)
-	g.Printf("var value any\n")
-	g.Printf("if err := json.Unmarshal(b, &value); err != nil {\n")
+	g.Printf("func (i *%s) UnmarshalYAML(value *yaml.Node) error {\n", typeName)
+	g.Printf("var s string\n")
+	g.Printf("if err := value.Decode(&s); err != nil {\n")
 	g.Printf("return err\n")
 	g.Printf("}\n")
-	g.Printf("switch v := value.(type) {\n")
-	g.Printf("case string:\n")
-	g.Printf("m, ok := %s(v)\n", lookupFunc)
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
 	g.Printf("if !ok {\n")
-	g.Printf("return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(%s(0))}\n", typeName)
+	g.Printf("return fmt.Errorf(\"line %%d: invalid %s: %%q\", value.Line, s)\n", typeName)
 	g.Printf("}\n")
 	g.Printf("*i = m\n")
-	g.Printf("case float64:\n")
-	g.Printf("*i = %s(v)\n", typeName)
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildXml(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n", typeName)
+	g.Printf("return e.EncodeElement(i.String(), start)\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n", typeName)
+	g.Printf("var s string\n")
+	g.Printf("if err := d.DecodeElement(&s, &start); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {\n", typeName)
+	g.Printf("return xml.Attr{Name: name, Value: i.String()}, nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalXMLAttr(attr xml.Attr) error {\n", typeName)
+	g.Printf("m, ok := %s(attr.Value)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", attr.Value)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildBson generates the BSON methods. Values are stored as their name,
+// or as an integer in "number" mode; both forms are accepted when decoding.
+func (g *Generator) buildBson(typeName string, signed bool) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalBSONValue() (byte, []byte, error) {\n", typeName)
+	if g.bson == "number" {
+		g.Printf("typ, data, err := bson.MarshalValue(%s(i))\n", intType(signed))
+	} else {
+		g.Printf("typ, data, err := bson.MarshalValue(i.String())\n")
+	}
+	g.Printf("return byte(typ), data, err\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalBSONValue(typ byte, data []byte) error {\n", typeName)
+	g.Printf("if bson.Type(typ) != bson.TypeString {\n")
+	g.Printf("var n %s\n", intType(signed))
+	g.Printf("if err := bson.UnmarshalValue(bson.Type(typ), data, &n); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("*i = %s(n)\n", typeName)
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+	g.Printf("var s string\n")
+	g.Printf("if err := bson.UnmarshalValue(bson.TypeString, data, &s); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildCbor(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalCBOR() ([]byte, error) {\n", typeName)
+	g.Printf("return cbor.Marshal(i.String())\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalCBOR(data []byte) error {\n", typeName)
+	g.Printf("var s string\n")
+	g.Printf("if err := cbor.Unmarshal(data, &s); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildMsgpack(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("// Unknown%[1]sNameError is returned when decoding an unknown name of %[1]s.\n", typeName)
+	g.Printf("type Unknown%sNameError struct {\n", typeName)
+	g.Printf("Name string\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (e *Unknown%sNameError) Error() string {\n", typeName)
+	g.Printf("return \"invalid %s: \" + strconv.Quote(e.Name)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i %s) EncodeMsgpack(enc *msgpack.Encoder) error {\n", typeName)
+	g.Printf("return enc.EncodeString(i.String())\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) DecodeMsgpack(dec *msgpack.Decoder) error {\n", typeName)
+	g.Printf("s, err := dec.DecodeString()\n")
+	g.Printf("if err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return &Unknown%sNameError{Name: s}\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildBinary generates the binary marshalers, encoding the value big-endian
+// in as many bytes as needed for the range of defined values.
+func (g *Generator) buildBinary(runs [][]Value, typeName string) {
+	lo, hi := runs[0][0], runs[len(runs)-1][len(runs[len(runs)-1])-1]
+	bits := vsize(lo, hi)
+	n := bits / 8
+
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalBinary() ([]byte, error) {\n", typeName)
+	g.Printf("return []byte{")
+	for k := n - 1; k >= 0; k-- {
+		if k > 0 {
+			g.Printf("byte(i >> %d), ", 8*k)
+		} else {
+			g.Printf("byte(i)")
+		}
+	}
+	g.Printf("}, nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalBinary(data []byte) error {\n", typeName)
+	g.Printf("if len(data) != %d {\n", n)
+	g.Printf("return fmt.Errorf(\"invalid %s: want %d bytes, got %%d\", len(data))\n", typeName, n)
+	g.Printf("}\n")
+	var b strings.Builder
+	for k := range n {
+		if k > 0 {
+			b.WriteString(" | ")
+		}
+		fmt.Fprintf(&b, "uint%d(data[%d])", bits, k)
+		if shift := 8 * (n - 1 - k); shift > 0 {
+			fmt.Fprintf(&b, "<<%d", shift)
+		}
+	}
+	if lo.signed {
+		g.Printf("v := %s(int%d(%s))\n", typeName, bits, b.String())
+	} else {
+		g.Printf("v := %s(%s)\n", typeName, b.String())
+	}
+	if lo.value == 0 && !lo.signed {
+		// For an unsigned lower bound of 0, "v < 0" would be redundant.
+		g.Printf("if v > %s {\n", &hi)
+	} else {
+		g.Printf("if v < %s || v > %s {\n", &lo, &hi)
+	}
+	g.Printf("return fmt.Errorf(\"invalid %s: %%d out of range\", v)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = v\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildGob(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	if g.gob == "register" {
+		g.Printf("\n")
+		g.Printf("func init() {\n")
+		g.Printf("gob.Register(%s(0))\n", typeName)
+		g.Printf("}\n")
+	}
+	g.Printf("\n")
+	g.Printf("func (i %s) GobEncode() ([]byte, error) {\n", typeName)
+	g.Printf("return []byte(i.String()), nil\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) GobDecode(data []byte) error {\n", typeName)
+	g.Printf("m, ok := %s(string(data))\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", data)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("*i = m\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildGraphql generates the gqlgen marshalers. GraphQL enum values are
+// conventionally written in SCREAMING_SNAKE_CASE, so the names are converted.
+func (g *Generator) buildGraphql(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalGQL(w io.Writer) {\n", typeName)
+	g.Printf("switch i {\n")
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("case %s:\n", v.original)
+			g.Printf("io.WriteString(w, %q)\n", strconv.Quote(screamingSnakeCase(v.repr, g.acronyms)))
+		}
+	}
+	g.Printf("default:\n")
+	g.Printf("io.WriteString(w, strconv.Quote(i.String()))\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) UnmarshalGQL(v any) error {\n", typeName)
+	g.Printf("s, ok := v.(string)\n")
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"%s must be a string, got %%T\", v)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("switch s {\n")
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("case %q:\n", screamingSnakeCase(v.repr, g.acronyms))
+			g.Printf("*i = %s\n", v.original)
+		}
+	}
+	g.Printf("default:\n")
+	g.Printf("return fmt.Errorf(\"invalid %s: %%q\", s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildSlog generates the slog.LogValuer implementation. Undefined values
+// are logged as a group holding both the String result and the raw number.
+func (g *Generator) buildSlog(typeName string, signed bool) {
+	g.Printf("\n")
+	g.Printf("func (i %s) LogValue() slog.Value {\n", typeName)
+	g.Printf("if _isValid_%s(i) {\n", typeName)
+	g.Printf("return slog.StringValue(i.String())\n")
+	g.Printf("}\n")
+	value := "slog.Int64(\"value\", int64(i))"
+	if !signed {
+		value = "slog.Uint64(\"value\", uint64(i))"
+	}
+	g.Printf("return slog.GroupValue(slog.String(\"name\", i.String()), %s)\n", value)
+	g.Printf("}\n")
+}
+
+func (g *Generator) buildZap(typeName string, signed bool) {
+	g.Printf("\n")
+	g.Printf("func (i %s) MarshalLogObject(enc zapcore.ObjectEncoder) error {\n", typeName)
+	g.Printf("enc.AddString(\"name\", i.String())\n")
+	if signed {
+		g.Printf("enc.AddInt64(\"value\", int64(i))\n")
+	} else {
+		g.Printf("enc.AddUint64(\"value\", uint64(i))\n")
+	}
+	g.Printf("return nil\n")
+	g.Printf("}\n")
+}
+
+// buildFormatter generates the fmt.Formatter implementation, formatting
+// the value as number for the integer verbs and as its name otherwise.
+func (g *Generator) buildFormatter(typeName string, signed bool) {
+	conv := "uint64"
+	if signed {
+		conv = "int64"
+	}
+	g.Printf("\n")
+	g.Printf("func (i %s) Format(f fmt.State, verb rune) {\n", typeName)
+	if g.gostring {
+		// A Formatter takes precedence over GoString for %#v.
+		g.Printf("if verb == 'v' && f.Flag('#') {\n")
+		g.Printf("fmt.Fprint(f, i.GoString())\n")
+		g.Printf("return\n")
+		g.Printf("}\n")
+	}
+	g.Printf("switch verb {\n")
+	g.Printf("case 'd', 'b', 'o', 'O', 'x', 'X':\n")
+	g.Printf("fmt.Fprintf(f, fmt.FormatString(f, verb), %s(i))\n", conv)
 	g.Printf("default:\n")
-	g.Printf("return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(%s(0))}\n", typeName)
+	g.Printf("fmt.Fprintf(f, fmt.FormatString(f, verb), i.String())\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildGoString generates the fmt.GoStringer implementation, returning
+// the name of the constant qualified by the package name.
+func (g *Generator) buildGoString(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("func (i %s) GoString() string {\n", typeName)
+	g.Printf("switch i {\n")
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("case %s:\n", v.original)
+			g.Printf("return %q\n", g.pkgName+"."+v.original)
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("return \"%s.%s(\" + %s + \")\"\n", g.pkgName, typeName, formatInt("i", runs[0][0].signed))
+	g.Printf("}\n")
+}
+
+// buildFlagValue generates the Set and Get methods, which together with String
+// implement flag.Getter. This also satisfies the Generic interface of urfave/cli.
+func (g *Generator) buildFlagValue(runs [][]Value, typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	var names []string
+	for _, values := range runs {
+		for _, v := range values {
+			names = append(names, v.repr)
+		}
+	}
+	g.Printf("\n")
+	g.Printf("func (i *%s) Set(s string) error {\n", typeName)
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return fmt.Errorf(\"invalid %s %%q, must be one of: %%s\", s, %q)\n", typeName, strings.Join(names, ", "))
 	g.Printf("}\n")
+	g.Printf("*i = m\n")
 	g.Printf("return nil\n")
 	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("func (i *%s) Get() any {\n", typeName)
+	g.Printf("return *i\n")
+	g.Printf("}\n")
+}
+
+// buildCobra generates the list of valid arguments and a completion
+// function for github.com/spf13/cobra.
+func (g *Generator) buildCobra(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// %sValidArgs lists the names of %s, for use as cobra.Command.ValidArgs.\n", typeName, typeName)
+	g.Printf("var %sValidArgs = []string{\n", typeName)
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("%q,\n", v.repr)
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// %sCompletion completes the names of %s, for use as cobra.Command.ValidArgsFunction\n", typeName, typeName)
+	g.Printf("// or with cobra.Command.RegisterFlagCompletionFunc.\n")
+	g.Printf("func %sCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {\n", typeName)
+	g.Printf("var names []string\n")
+	g.Printf("for _, name := range %sValidArgs {\n", typeName)
+	g.Printf("if strings.HasPrefix(name, toComplete) {\n")
+	g.Printf("names = append(names, name)\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("return names, cobra.ShellCompDirectiveNoFileComp\n")
+	g.Printf("}\n")
+}
+
+// buildKong generates the kong.MapperValue implementation, relying on
+// the Set method of buildFlagValue.
+func (g *Generator) buildKong(typeName string) {
+	g.Printf("\n")
+	g.Printf("func (i *%s) Decode(ctx *kong.DecodeContext) error {\n", typeName)
+	g.Printf("var s string\n")
+	g.Printf("if err := ctx.Scan.PopValueInto(\"value\", &s); err != nil {\n")
+	g.Printf("return err\n")
+	g.Printf("}\n")
+	g.Printf("return i.Set(s)\n")
+	g.Printf("}\n")
+}
+
+// buildMapstructure generates a decode hook converting names into the type.
+// Other data is left to mapstructure, which already decodes numbers.
+func (g *Generator) buildMapstructure(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	g.Printf("\n")
+	g.Printf("// %[1]sDecodeHook returns a mapstructure.DecodeHookFunc decoding names of %[1]s.\n", typeName)
+	g.Printf("func %sDecodeHook() mapstructure.DecodeHookFunc {\n", typeName)
+	g.Printf("return func(from, to reflect.Type, data any) (any, error) {\n")
+	g.Printf("s, ok := data.(string)\n")
+	g.Printf("if !ok || to != reflect.TypeFor[%s]() {\n", typeName)
+	g.Printf("return data, nil\n")
+	g.Printf("}\n")
+	g.Printf("m, ok := %s(s)\n", lookupFunc)
+	g.Printf("if !ok {\n")
+	g.Printf("return nil, fmt.Errorf(\"invalid %s: %%q\", s)\n", typeName)
+	g.Printf("}\n")
+	g.Printf("return m, nil\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildError generates the error interface for error codes. Values without
+// a line comment use their name as message.
+func (g *Generator) buildError(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("func (i %s) Error() string {\n", typeName)
+	g.Printf("switch i {\n")
+	for _, values := range runs {
+		for _, v := range values {
+			if v.comment == "" {
+				continue
+			}
+			g.Printf("case %s:\n", v.original)
+			g.Printf("return %q\n", v.comment)
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("return i.String()\n")
+	g.Printf("}\n")
+	if g.error == "is" {
+		g.Printf("\n")
+		g.Printf("// Is reports whether target is the same error code, given as %s or *%s.\n", typeName, typeName)
+		g.Printf("func (i %s) Is(target error) bool {\n", typeName)
+		g.Printf("switch t := target.(type) {\n")
+		g.Printf("case %s:\n", typeName)
+		g.Printf("return i == t\n")
+		g.Printf("case *%s:\n", typeName)
+		g.Printf("return t != nil && i == *t\n")
+		g.Printf("}\n")
+		g.Printf("return false\n")
+		g.Printf("}\n")
+	}
+}
+
+// buildValidator generates a validation for go-playground/validator. Fields
+// of the type itself are checked by their value, strings by their name.
+func (g *Generator) buildValidator(typeName string) {
+	lookupFunc := strings.Replace(g.lookup, "{}", typeName, 1)
+	tag := strings.ToLower(typeName)
+	g.Printf("\n")
+	g.Printf("// Validate%s reports whether the field holds a defined %s, either by value or by name.\n", typeName, typeName)
+	g.Printf("func Validate%s(fl validator.FieldLevel) bool {\n", typeName)
+	g.Printf("field := fl.Field()\n")
+	g.Printf("switch field.Kind() {\n")
+	g.Printf("case reflect.String:\n")
+	g.Printf("_, ok := %s(field.String())\n", lookupFunc)
+	g.Printf("return ok\n")
+	g.Printf("case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:\n")
+	g.Printf("return _isValid_%s(%s(field.Int())) && int64(%s(field.Int())) == field.Int()\n", typeName, typeName, typeName)
+	g.Printf("case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:\n")
+	g.Printf("return _isValid_%s(%s(field.Uint())) && uint64(%s(field.Uint())) == field.Uint()\n", typeName, typeName, typeName)
+	g.Printf("}\n")
+	g.Printf("return false\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// Register%sValidation registers Validate%s as the tag %q.\n", typeName, typeName, tag)
+	g.Printf("func Register%sValidation(v *validator.Validate) {\n", typeName)
+	g.Printf("if err := v.RegisterValidation(%q, Validate%s); err != nil {\n", tag, typeName)
+	g.Printf("panic(err) // Only fails for invalid tags.\n")
+	g.Printf("}\n")
+	g.Printf("}\n")
+}
+
+// buildPrometheus generates the label helpers for Prometheus metrics.
+func (g *Generator) buildPrometheus(runs [][]Value, typeName string) {
+	g.Printf("\n")
+	g.Printf("// %sLabelValues returns the names of %s, for creating the children of a metric vector\n", typeName, typeName)
+	g.Printf("// up front.\n")
+	g.Printf("func %sLabelValues() []string {\n", typeName)
+	g.Printf("return []string{\n")
+	for _, values := range runs {
+		for _, v := range values {
+			g.Printf("%q,\n", v.repr)
+		}
+	}
+	g.Printf("}\n")
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// PromLabel returns the name of the value, for use as a metric label.\n")
+	g.Printf("func (i %s) PromLabel() string {\n", typeName)
+	g.Printf("return i.String()\n")
+	g.Printf("}\n")
+}
+
+// buildProto generates the conversions from and to the protobuf enum. The
+// values are checked against the protobuf constants, so the conversions
+// only need to change the type.
+func (g *Generator) buildProto(runs [][]Value, typeName string) {
+	_, protoType, _ := strings.Cut(g.proto, ".")
+	for _, values := range runs {
+		for _, v := range values {
+			name, ok := g.protoConsts[v.value]
+			if !ok {
+				failf("%s has no value %s for %s", g.proto, v.str, v.original)
+			}
+			// protoc-gen-go prefixes the constants with the enum name.
+			name = strings.TrimPrefix(name, protoType+"_")
+			if want := screamingSnakeCase(v.repr, g.acronyms); name != want && !strings.HasSuffix(name, "_"+want) {
+				log.Printf("warning: %s is %s in %s", v.original, name, g.proto)
+			}
+		}
+	}
+	g.Printf("\n")
+	g.Printf("// ToProto converts the value to %s.\n", g.proto)
+	g.Printf("func (i %s) ToProto() %s {\n", typeName, g.proto)
+	g.Printf("return %s(i)\n", g.proto)
+	g.Printf("}\n")
+	g.Printf("\n")
+	g.Printf("// %sFromProto converts %s to %s, reporting whether it is a defined value.\n", typeName, g.proto, typeName)
+	g.Printf("func %sFromProto(p %s) (%s, bool) {\n", typeName, g.proto, typeName)
+	g.Printf("i := %s(p)\n", typeName)
+	g.Printf("return i, _isValid_%s(i)\n", typeName)
+	g.Printf("}\n")
 }
@@ -10,6 +10,9 @@
 package main
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
@@ -35,10 +38,14 @@ var golden = []Golden{
 	{"num", "", false, num_in, num_out},
 	{"unum", "", false, unum_in, unum_out},
 	{"unumpos", "", false, unumpos_in, unumpos_out},
+	{"big", "", false, big_in, big_out},
 	{"prime", "", false, prime_in, prime_out},
 	{"prefix", "Type", false, prefix_in, prefix_out},
+	{"prefixes", "Status,St", false, prefixes_in, prefixes_out},
 	{"tokens", "", true, tokens_in, tokens_out},
 	{"overflow8", "", false, overflow8_in, overflow8_out},
+	{"directive", "", true, directive_in, directive_out},
+	{"sentinel", "", false, sentinel_in, sentinel_out},
 }
 
 // Each example starts with "type XXX [u]int", with a single space separating them.
@@ -90,7 +97,7 @@ const (
 	One
 	Two
 	Three
-	AnotherOne = One  // Duplicate; note that AnotherOne doesn't appear below.
+	AnotherOne = One  // Duplicate; note that AnotherOne is only checked, not named below.
 )
 `
 
@@ -101,6 +108,7 @@ const offset_out = `func _() {
 	_ = x[One-1]
 	_ = x[Two-2]
 	_ = x[Three-3]
+	_ = x[AnotherOne-1]
 }
 
 const _Number_name = "OneTwoThree"
@@ -249,7 +257,7 @@ func (i Unum) String() string {
 		i -= 253
 		return _Unum_name_1[_Unum_index_1[i]:_Unum_index_1[i+1]]
 	default:
-		return "Unum(" + strconv.FormatInt(int64(i), 10) + ")"
+		return "Unum(" + strconv.FormatUint(uint64(i), 10) + ")"
 	}
 }
 `
@@ -298,11 +306,69 @@ func (i Unumpos) String() string {
 		i -= 253
 		return _Unumpos_name_1[_Unumpos_index_1[i]:_Unumpos_index_1[i+1]]
 	default:
-		return "Unumpos(" + strconv.FormatInt(int64(i), 10) + ")"
+		return "Unumpos(" + strconv.FormatUint(uint64(i), 10) + ")"
 	}
 }
 `
 
+// Unsigned values above MaxInt64, sparse enough for a map.
+const big_in = `type Big uint64
+const (
+	b0 Big = 0
+	b1 Big = 1 << 8
+	b2 Big = 1 << 16
+	b3 Big = 1 << 24
+	b4 Big = 1 << 32
+	b5 Big = 1 << 40
+	b6 Big = 1 << 48
+	b7 Big = 1 << 56
+	b8 Big = 1 << 62
+	b9 Big = 1 << 63
+	b10 Big = 1<<64 - 1
+)
+`
+
+const big_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[b0-0]
+	_ = x[b1-256]
+	_ = x[b2-65536]
+	_ = x[b3-16777216]
+	_ = x[b4-4294967296]
+	_ = x[b5-1099511627776]
+	_ = x[b6-281474976710656]
+	_ = x[b7-72057594037927936]
+	_ = x[b8-4611686018427387904]
+	_ = x[b9-9223372036854775808]
+	_ = x[b10-18446744073709551615]
+}
+
+const _Big_name = "b0b1b2b3b4b5b6b7b8b9b10"
+
+var _Big_map = map[Big]string{
+	0:                    _Big_name[0:2],
+	256:                  _Big_name[2:4],
+	65536:                _Big_name[4:6],
+	16777216:             _Big_name[6:8],
+	4294967296:           _Big_name[8:10],
+	1099511627776:        _Big_name[10:12],
+	281474976710656:      _Big_name[12:14],
+	72057594037927936:    _Big_name[14:16],
+	4611686018427387904:  _Big_name[16:18],
+	9223372036854775808:  _Big_name[18:20],
+	18446744073709551615: _Big_name[20:23],
+}
+
+func (i Big) String() string {
+	if str, ok := _Big_map[i]; ok {
+		return str
+	}
+	return "Big(" + strconv.FormatUint(uint64(i), 10) + ")"
+}
+`
+
 // Enough gaps to trigger a map implementation of the method.
 // Also includes a duplicate to test that it doesn't cause problems
 const prime_in = `type Prime int
@@ -408,6 +474,64 @@ func (i Type) String() string {
 }
 `
 
+// Several prefixes: the first matching one is trimmed.
+const declorder_in = `type Priority int
+const (
+	Medium Priority = 1
+	Low    Priority = 0
+	High   Priority = 2
+)
+`
+
+const label_in = `type Status int
+const (
+	//morestringer:label en="Active" de="Aktiv"
+	Active Status = iota
+	Stopped //morestringer:label en="Stopped" de="Angehalten" de-CH="Gestoppt"
+	Unknown
+)
+`
+
+const strconst_in = `type Color string
+const (
+	Red   Color = "red"
+	Green Color = "green"
+	Scarlet     = Red
+)
+`
+
+const prefixes_in = `type Status int
+const (
+	StatusActive Status = iota
+	StIdle
+	StatusStopped
+	Unknown
+)
+`
+
+const prefixes_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[StatusActive-0]
+	_ = x[StIdle-1]
+	_ = x[StatusStopped-2]
+	_ = x[Unknown-3]
+}
+
+const _Status_name = "ActiveIdleStoppedUnknown"
+
+var _Status_index = [...]uint8{0, 6, 10, 17, 24}
+
+func (i Status) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Status_index)-1 {
+		return "Status(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Status_name[_Status_index[idx]:_Status_index[idx+1]]
+}
+`
+
 const tokens_in = `type Token int
 const (
 	And Token = iota // &
@@ -989,6 +1113,72 @@ func (i Overflow8) String() string {
 }
 `
 
+// A line comment holding only a directive is not used as name.
+const directive_in = `type Directive int
+const (
+	Plus Directive = iota // +
+	Minus //morestringer:alias -
+)
+`
+
+const directive_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Plus-0]
+	_ = x[Minus-1]
+}
+
+const _Directive_name = "+Minus"
+
+var _Directive_index = [...]uint8{0, 1, 6}
+
+func (i Directive) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Directive_index)-1 {
+		return "Directive(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Directive_name[_Directive_index[idx]:_Directive_index[idx+1]]
+}
+`
+
+// Constants are checked against the sentinel counting them.
+const sentinel_in = `type Sentinel int
+const (
+	First Sentinel = iota
+	Second
+	Third
+	sentinelCount
+)
+const Second2 = Second
+`
+
+const sentinel_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[First-0]
+	_ = x[Second-1]
+	_ = x[Third-2]
+	_ = x[Second2-1]
+	// An "invalid array index" compiler error signifies that constants were added or removed.
+	_ = x[sentinelCount-3]
+	_ = [sentinelCount]struct{}{First: {}, Second: {}, Third: {}}
+}
+
+const _Sentinel_name = "FirstSecondThird"
+
+var _Sentinel_index = [...]uint8{0, 5, 11, 16}
+
+func (i Sentinel) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Sentinel_index)-1 {
+		return "Sentinel(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Sentinel_name[_Sentinel_index[idx]:_Sentinel_index[idx+1]]
+}
+`
+
 func TestGolden(t *testing.T) {
 	testenv.NeedsTool(t, "go")
 
@@ -1003,7 +1193,7 @@ func TestGolden(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			pkg := memPackage(input, test.trimPrefix, test.lineComment, false)
+			pkg := memPackage(input, naming{trimPrefix: test.trimPrefix, lineComment: test.lineComment})
 
 			// Extract the name and type of the constant from the first line.
 			tokens := strings.SplitN(test.input, " ", 3)
@@ -1012,7 +1202,9 @@ func TestGolden(t *testing.T) {
 			}
 
 			g := Generator{}
-			g.genType(tokens[1], pkg.findValues(tokens[1])[tokens[1]])
+			values := pkg.findValues(tokens[1])[tokens[1]]
+			g.sentinels = pkg.sentinels
+			g.genType(tokens[1], values)
 			got := string(g.format())
 			if got != test.output {
 				t.Errorf("file %s does not have the expected content:\n%s", test.name, diffp.Diff("want", []byte(test.output), "got", []byte(got)))
@@ -1020,3 +1212,2944 @@ func TestGolden(t *testing.T) {
 		})
 	}
 }
+
+// GoldenOptions represents a test case for methods generated on request.
+type GoldenOptions struct {
+	name   string
+	gen    Generator // generator with the options under test set.
+	input  string    // input; the package clause is provided when running the test.
+	output string    // expected output.
+}
+
+var goldenOptions = []GoldenOptions{
+	{"yaml", Generator{yaml: true}, level_in, yaml_out},
+	{"bson", Generator{bson: "number"}, level_in, bson_out},
+	{"cbor", Generator{cbor: true}, level_in, cbor_out},
+	{"msgpack", Generator{msgpack: true}, level_in, msgpack_out},
+	{"jsonv2", Generator{jsonv2: "name"}, level_in, jsonv2_out},
+	{"graphql", Generator{graphql: true}, level_in, graphql_out},
+	{"zap", Generator{zap: true}, level_in, zap_out},
+	{"cobra", Generator{cobra: true}, level_in, cobra_out},
+	{"kong", Generator{flagValue: true, kong: true}, level_in, kong_out},
+	{"mapstructure", Generator{mapstructure: true}, level_in, mapstructure_out},
+	{"proto", Generator{proto: "pb.Level", protoConsts: map[uint64]string{0: "Level_LOW", 1: "Level_HIGH"}}, level_in, proto_out},
+	{"prometheus", Generator{prometheus: true}, level_in, prometheus_out},
+	{"validator", Generator{validator: true}, level_in, validator_out},
+	{"parse", Generator{parse: "error"}, level_in, parse_out},
+	{"fold", Generator{lookup: "{}ByName", fold: true}, level_in, fold_out},
+	{"lookupbinary", Generator{lookup: "{}ByName", lookupStrategy: "binary"}, level_in, lookupbinary_out},
+	{"lookupmap", Generator{lookup: "{}ByName", lookupStrategy: "map"}, level_in, lookupmap_out},
+	{"lookupperfect", Generator{lookup: "{}ByName", lookupStrategy: "perfect"}, level_in, lookupperfect_out},
+	{"values", Generator{values: true}, level_in, values_out},
+	{"count", Generator{count: "exported"}, level_in, count_out},
+	{"minmax", Generator{minMax: true}, level_in, minmax_out},
+	{"isvalid", Generator{isValid: true}, level_in, isvalid_out},
+	{"iter", Generator{iter: "all2"}, level_in, iter_out},
+	{"next", Generator{next: "clamp"}, level_in, next_out},
+	{"ordinal", Generator{ordinal: true}, level_in, ordinal_out},
+	{"random", Generator{random: true}, level_in, random_out},
+	{"set", Generator{set: true}, level_in, set_out},
+	{"enummap", Generator{enumMap: true, ordinal: true}, level_in, enummap_out},
+	{"validate", Generator{validate: true}, level_in, validate_out},
+	{"clamp", Generator{clamp: true}, level_in, clamp_out},
+	{"predicates", Generator{predicates: true}, level_in, predicates_out},
+	{"enums", Generator{enums: true}, level_in, enums_out},
+	{"runtime", Generator{runtime: true}, level_in, runtime_out},
+	{"wirename", Generator{wireName: "kebab"}, level_in, wirename_out},
+	{"debugstring", Generator{debugString: true}, level_in, debugstring_out},
+	{"fingerprint", Generator{fingerprint: true}, level_in, fingerprint_out},
+	{"options", Generator{options: true}, level_in, options_out},
+	{"usage", Generator{usage: true}, level_in, usage_out},
+	{"templatefuncs", Generator{templateFuncs: true, parse: "error", values: true}, level_in, templatefuncs_out},
+	{"flags", Generator{flags: true, flagSep: ", "}, flags_in, flags_out},
+	{"flagparse", Generator{flags: true, flagSep: "|", parse: "error"}, flags_in, flagparse_out},
+	{"flagiter", Generator{flags: true, flagSep: "|", iter: "all"}, flags_in, flagiter_out},
+	{"flagjson", Generator{flags: true, flagSep: "|", json: "name", unknown: "error"}, flags_in, flagjson_out},
+	{"flagdecimal", Generator{flags: true, flagSep: "|", flagUnknown: "decimal"}, flags_in, flagdecimal_out},
+	{"declorder", Generator{declOrder: true, values: true}, declorder_in, declorder_out},
+	{"i18n", Generator{catalogs: map[string]catalog{"de": {"Level": {"Low": "Niedrig", "High": "Hoch"}}, "fr": {"Level": {"High": "Haut"}}}}, level_in, i18n_out},
+	{"message", Generator{message: true}, level_in, message_out},
+	{"label", Generator{label: true}, label_in, label_out},
+	{"strconst", Generator{parse: "error"}, strconst_in, strconst_out},
+	{"frompkg", Generator{from: &Package{name: "levels"}}, level_in, frompkg_out},
+	{"slice", Generator{slice: true, parse: "error"}, level_in, slice_out},
+}
+
+const level_in = `type Level int
+const (
+	Low Level = iota
+	High
+)
+`
+
+const flags_in = `type Perm uint8
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+	All = Read | Write | Exec
+)
+`
+
+const yaml_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i Level) MarshalYAML() (any, error) {
+	return i.String(), nil
+}
+
+func (i *Level) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	m, ok := _lookup_Level(s)
+	if !ok {
+		return fmt.Errorf("line %d: invalid Level: %q", value.Line, s)
+	}
+	*i = m
+	return nil
+}
+`
+
+const bson_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i Level) MarshalBSONValue() (byte, []byte, error) {
+	typ, data, err := bson.MarshalValue(int64(i))
+	return byte(typ), data, err
+}
+
+func (i *Level) UnmarshalBSONValue(typ byte, data []byte) error {
+	if bson.Type(typ) != bson.TypeString {
+		var n int64
+		if err := bson.UnmarshalValue(bson.Type(typ), data, &n); err != nil {
+			return err
+		}
+		*i = Level(n)
+		return nil
+	}
+	var s string
+	if err := bson.UnmarshalValue(bson.TypeString, data, &s); err != nil {
+		return err
+	}
+	m, ok := _lookup_Level(s)
+	if !ok {
+		return fmt.Errorf("invalid Level: %q", s)
+	}
+	*i = m
+	return nil
+}
+`
+
+const cbor_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i Level) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(i.String())
+}
+
+func (i *Level) UnmarshalCBOR(data []byte) error {
+	var s string
+	if err := cbor.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	m, ok := _lookup_Level(s)
+	if !ok {
+		return fmt.Errorf("invalid Level: %q", s)
+	}
+	*i = m
+	return nil
+}
+`
+
+const msgpack_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// UnknownLevelNameError is returned when decoding an unknown name of Level.
+type UnknownLevelNameError struct {
+	Name string
+}
+
+func (e *UnknownLevelNameError) Error() string {
+	return "invalid Level: " + strconv.Quote(e.Name)
+}
+
+func (i Level) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeString(i.String())
+}
+
+func (i *Level) DecodeMsgpack(dec *msgpack.Decoder) error {
+	s, err := dec.DecodeString()
+	if err != nil {
+		return err
+	}
+	m, ok := _lookup_Level(s)
+	if !ok {
+		return &UnknownLevelNameError{Name: s}
+	}
+	*i = m
+	return nil
+}
+`
+
+const jsonv2_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i Level) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(i.String()))
+}
+
+func (i *Level) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+	switch tok.Kind() {
+	case '"':
+		m, ok := _lookup_Level(tok.String())
+		if !ok {
+			return fmt.Errorf("invalid Level: %q", tok.String())
+		}
+		*i = m
+	case '0':
+		n, err := tok.Int()
+		if err != nil {
+			return err
+		}
+		*i = Level(n)
+	default:
+		return fmt.Errorf("invalid Level: unexpected %s", tok.Kind())
+	}
+	return nil
+}
+`
+
+const graphql_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i Level) MarshalGQL(w io.Writer) {
+	switch i {
+	case Low:
+		io.WriteString(w, "\"LOW\"")
+	case High:
+		io.WriteString(w, "\"HIGH\"")
+	default:
+		io.WriteString(w, strconv.Quote(i.String()))
+	}
+}
+
+func (i *Level) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Level must be a string, got %T", v)
+	}
+	switch s {
+	case "LOW":
+		*i = Low
+	case "HIGH":
+		*i = High
+	default:
+		return fmt.Errorf("invalid Level: %q", s)
+	}
+	return nil
+}
+`
+
+const zap_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i Level) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", i.String())
+	enc.AddInt64("value", int64(i))
+	return nil
+}
+`
+
+const cobra_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelValidArgs lists the names of Level, for use as cobra.Command.ValidArgs.
+var LevelValidArgs = []string{
+	"Low",
+	"High",
+}
+
+// LevelCompletion completes the names of Level, for use as cobra.Command.ValidArgsFunction
+// or with cobra.Command.RegisterFlagCompletionFunc.
+func LevelCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, name := range LevelValidArgs {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+`
+
+const kong_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func (i *Level) Set(s string) error {
+	m, ok := _lookup_Level(s)
+	if !ok {
+		return fmt.Errorf("invalid Level %q, must be one of: %s", s, "Low, High")
+	}
+	*i = m
+	return nil
+}
+
+func (i *Level) Get() any {
+	return *i
+}
+
+func (i *Level) Decode(ctx *kong.DecodeContext) error {
+	var s string
+	if err := ctx.Scan.PopValueInto("value", &s); err != nil {
+		return err
+	}
+	return i.Set(s)
+}
+`
+
+const mapstructure_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelDecodeHook returns a mapstructure.DecodeHookFunc decoding names of Level.
+func LevelDecodeHook() mapstructure.DecodeHookFunc {
+	return func(from, to reflect.Type, data any) (any, error) {
+		s, ok := data.(string)
+		if !ok || to != reflect.TypeFor[Level]() {
+			return data, nil
+		}
+		m, ok := _lookup_Level(s)
+		if !ok {
+			return nil, fmt.Errorf("invalid Level: %q", s)
+		}
+		return m, nil
+	}
+}
+`
+
+const proto_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _isValid_Level(i Level) bool {
+	switch {
+	case 0 <= i && i <= 1:
+		return true
+	}
+	return false
+}
+
+// ToProto converts the value to pb.Level.
+func (i Level) ToProto() pb.Level {
+	return pb.Level(i)
+}
+
+// LevelFromProto converts pb.Level to Level, reporting whether it is a defined value.
+func LevelFromProto(p pb.Level) (Level, bool) {
+	i := Level(p)
+	return i, _isValid_Level(i)
+}
+`
+
+const prometheus_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelLabelValues returns the names of Level, for creating the children of a metric vector
+// up front.
+func LevelLabelValues() []string {
+	return []string{
+		"Low",
+		"High",
+	}
+}
+
+// PromLabel returns the name of the value, for use as a metric label.
+func (i Level) PromLabel() string {
+	return i.String()
+}
+`
+
+const validator_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _isValid_Level(i Level) bool {
+	switch {
+	case 0 <= i && i <= 1:
+		return true
+	}
+	return false
+}
+
+// ValidateLevel reports whether the field holds a defined Level, either by value or by name.
+func ValidateLevel(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		_, ok := _lookup_Level(field.String())
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return _isValid_Level(Level(field.Int())) && int64(Level(field.Int())) == field.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return _isValid_Level(Level(field.Uint())) && uint64(Level(field.Uint())) == field.Uint()
+	}
+	return false
+}
+
+// RegisterLevelValidation registers ValidateLevel as the tag "level".
+func RegisterLevelValidation(v *validator.Validate) {
+	if err := v.RegisterValidation("level", ValidateLevel); err != nil {
+		panic(err) // Only fails for invalid tags.
+	}
+}
+`
+
+const parse_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _suggest_Level(name string) string {
+	a := []rune(strings.ToLower(name))
+	best, bestDist := "", 0
+	for _, n := range [...]string{"Low", "High"} {
+		b := []rune(strings.ToLower(n))
+		// Levenshtein distance, keeping a single row.
+		row := make([]int, len(b)+1)
+		for j := range row {
+			row[j] = j
+		}
+		for i := range a {
+			prev := row[0]
+			row[0] = i + 1
+			for j := range b {
+				cost := 1
+				if a[i] == b[j] {
+					cost = 0
+				}
+				prev, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, prev+cost)
+			}
+		}
+		if d := row[len(b)]; d <= max(2, len(b)/3) && (best == "" || d < bestDist) {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// ErrInvalidLevel is wrapped by the error of ParseLevel for unknown names.
+var ErrInvalidLevel = errors.New("invalid Level")
+
+// ParseLevel returns the Level with the given name.
+func ParseLevel(name string) (Level, error) {
+	i, ok := _lookup_Level(name)
+	if !ok {
+		if s := _suggest_Level(name); s != "" {
+			return 0, fmt.Errorf("%w: %q, did you mean %q?", ErrInvalidLevel, name, s)
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, name)
+	}
+	return i, nil
+}
+`
+
+const fold_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _canonical_Level(name string) string {
+	name = norm.NFC.String(name)
+	return strings.Map(func(r rune) rune {
+		m := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			m = min(m, f)
+		}
+		return m
+	}, name)
+}
+
+func LevelByName(name string) (Level, bool) {
+	name = _canonical_Level(name)
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0x7b2feefd:
+		if name == "HIGH" {
+			return High, true
+		}
+	case 0xd22be661:
+		if name == "LOW" {
+			return Low, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+`
+
+const lookupbinary_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name_lookup = "HighLow"
+
+var _Level_index_lookup = [...]uint8{0, 4, 7}
+var _Level_value_lookup = [...]Level{
+	High,
+	Low,
+}
+
+func LevelByName(name string) (Level, bool) {
+	lo, hi := 0, len(_Level_value_lookup)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		s := _Level_name_lookup[_Level_index_lookup[mid]:_Level_index_lookup[mid+1]]
+		if name == s {
+			return _Level_value_lookup[mid], true
+		}
+		if name < s {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+`
+
+const lookupmap_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+var _Level_lookup = map[string]Level{
+	"Low":  Low,
+	"High": High,
+}
+
+func LevelByName(name string) (Level, bool) {
+	value, ok := _Level_lookup[name]
+	return value, ok
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+`
+
+const lookupperfect_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name_perfect = "HighLow"
+
+var _Level_index_perfect = [...]uint8{0, 4, 7}
+var _Level_value_perfect = [...]Level{
+	High,
+	Low,
+}
+
+var _Level_seed_perfect = [...]uint8{1, 2}
+
+func _hash_Level(seed uint32, s string) uint32 {
+	h := 2166136261 ^ seed
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h ^ h>>16
+}
+
+func LevelByName(name string) (Level, bool) {
+	const n = 2
+	seed := uint32(_Level_seed_perfect[_hash_Level(0, name)%n])
+	slot := _hash_Level(seed, name) % n
+	if name != _Level_name_perfect[_Level_index_perfect[slot]:_Level_index_perfect[slot+1]] {
+		return 0, false
+	}
+	return _Level_value_perfect[slot], true
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+`
+
+const values_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelValues returns all values of Level in ascending order.
+func LevelValues() []Level {
+	return []Level{Low, High}
+}
+
+// LevelNames returns the names of all values of Level, in the order of LevelValues.
+func LevelNames() []string {
+	return []string{
+		_Level_name[0:3],
+		_Level_name[3:7],
+	}
+}
+`
+
+const count_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// _LevelCount is the number of values of Level.
+const _LevelCount = 2
+
+// NumLevel is the number of values of Level.
+const NumLevel = _LevelCount
+`
+
+const minmax_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelMin returns the smallest value of Level.
+func LevelMin() Level {
+	return Low
+}
+
+// LevelMax returns the largest value of Level.
+func LevelMax() Level {
+	return High
+}
+`
+
+const isvalid_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _isValid_Level(i Level) bool {
+	switch {
+	case 0 <= i && i <= 1:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether i is a defined value of Level.
+func (i Level) IsValid() bool {
+	return _isValid_Level(i)
+}
+`
+
+const iter_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelAll returns an iterator over all values of Level in ascending order.
+func LevelAll() iter.Seq[Level] {
+	return func(yield func(Level) bool) {
+		for _, i := range [...]Level{Low, High} {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// LevelAll2 returns an iterator over all values of Level and their names in ascending order.
+func LevelAll2() iter.Seq2[Level, string] {
+	return func(yield func(Level, string) bool) {
+		for _, i := range [...]Level{Low, High} {
+			if !yield(i, i.String()) {
+				return
+			}
+		}
+	}
+}
+`
+
+const next_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// Next returns the smallest value of Level greater than i,
+// or the largest value if there is none.
+func (i Level) Next() Level {
+	switch {
+	case i < 0:
+		return Low
+	case i < 1:
+		return i + 1
+	}
+	return High
+}
+
+// Prev returns the largest value of Level less than i,
+// or the smallest value if there is none.
+func (i Level) Prev() Level {
+	switch {
+	case i > 1:
+		return High
+	case i > 0:
+		return i - 1
+	}
+	return Low
+}
+`
+
+const ordinal_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// Ordinal returns the index of i among the values of Level in ascending order,
+// or -1 if i is not defined.
+func (i Level) Ordinal() int {
+	switch {
+	case 0 <= i && i <= 1:
+		return int(i)
+	}
+	return -1
+}
+
+// LevelFromOrdinal returns the value of Level with the given index, as returned by Ordinal.
+func LevelFromOrdinal(n int) (Level, bool) {
+	switch {
+	case 0 <= n && n < 2:
+		return Level(n), true
+	}
+	return 0, false
+}
+`
+
+const random_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// RandomLevel returns a random value of Level, each value being equally likely.
+func RandomLevel(r *rand.Rand) Level {
+	values := [...]Level{Low, High}
+	return values[r.Intn(len(values))]
+}
+
+// Generate implements testing/quick.Generator, generating random values of Level.
+func (Level) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RandomLevel(r))
+}
+`
+
+const set_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelSet is a set of Level values.
+type LevelSet uint64
+
+// NewLevelSet returns a set holding the given values.
+func NewLevelSet(values ...Level) LevelSet {
+	var s LevelSet
+	for _, i := range values {
+		s.Add(i)
+	}
+	return s
+}
+
+func _LevelSet_bit(i Level) (uint, bool) {
+	if i < 0 || i > 1 {
+		return 0, false
+	}
+	return uint(i), true
+}
+
+// Add adds i to the set. Values outside the range of Level are ignored.
+func (s *LevelSet) Add(i Level) {
+	if n, ok := _LevelSet_bit(i); ok {
+		*s |= 1 << n
+	}
+}
+
+// Remove removes i from the set.
+func (s *LevelSet) Remove(i Level) {
+	if n, ok := _LevelSet_bit(i); ok {
+		*s &^= 1 << n
+	}
+}
+
+// Contains reports whether i is in the set.
+func (s LevelSet) Contains(i Level) bool {
+	n, ok := _LevelSet_bit(i)
+	return ok && s&(1<<n) != 0
+}
+
+// Len returns the number of values in the set.
+func (s LevelSet) Len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// Iterate calls yield for the values in the set in ascending order, until
+// yield returns false. It can be ranged over as in "for i := range s.Iterate".
+func (s LevelSet) Iterate(yield func(Level) bool) {
+	for w := uint64(s); w != 0; w &= w - 1 {
+		n := bits.TrailingZeros64(w)
+		if !yield(Level(n)) {
+			return
+		}
+	}
+}
+
+// String returns the names of the values in the set, as in "{A, B}".
+func (s LevelSet) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := range s.Iterate {
+		if b.Len() > 1 {
+			b.WriteString(", ")
+		}
+		b.WriteString(i.String())
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+`
+
+const enummap_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// Ordinal returns the index of i among the values of Level in ascending order,
+// or -1 if i is not defined.
+func (i Level) Ordinal() int {
+	switch {
+	case 0 <= i && i <= 1:
+		return int(i)
+	}
+	return -1
+}
+
+// LevelFromOrdinal returns the value of Level with the given index, as returned by Ordinal.
+func LevelFromOrdinal(n int) (Level, bool) {
+	switch {
+	case 0 <= n && n < 2:
+		return Level(n), true
+	}
+	return 0, false
+}
+
+// LevelMap maps values of Level to values of V without hashing, storing
+// them in an array indexed by the ordinal of the key.
+type LevelMap[V any] struct {
+	values [2]V
+	set    [2]bool
+}
+
+// Get returns the value stored for i and whether there is one.
+func (m *LevelMap[V]) Get(i Level) (V, bool) {
+	if n := i.Ordinal(); n >= 0 && m.set[n] {
+		return m.values[n], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores v for i. Values of Level which are not defined are ignored.
+func (m *LevelMap[V]) Set(i Level, v V) {
+	if n := i.Ordinal(); n >= 0 {
+		m.values[n], m.set[n] = v, true
+	}
+}
+
+// Delete removes the value stored for i.
+func (m *LevelMap[V]) Delete(i Level) {
+	if n := i.Ordinal(); n >= 0 {
+		var zero V
+		m.values[n], m.set[n] = zero, false
+	}
+}
+
+// Len returns the number of values stored.
+func (m *LevelMap[V]) Len() int {
+	n := 0
+	for _, ok := range m.set {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Range calls yield for the stored keys in ascending order and their values,
+// until yield returns false. It can be ranged over as in "for i, v := range m.Range".
+func (m *LevelMap[V]) Range(yield func(Level, V) bool) {
+	for n, ok := range m.set {
+		if !ok {
+			continue
+		}
+		i, _ := LevelFromOrdinal(n)
+		if !yield(i, m.values[n]) {
+			return
+		}
+	}
+}
+`
+
+const validate_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _isValid_Level(i Level) bool {
+	switch {
+	case 0 <= i && i <= 1:
+		return true
+	}
+	return false
+}
+
+// InvalidLevelError is returned by Validate for values which are not defined.
+type InvalidLevelError struct {
+	Value Level
+}
+
+func (e *InvalidLevelError) Error() string {
+	return "invalid Level: " + strconv.FormatInt(int64(e.Value), 10)
+}
+
+// Validate returns an *InvalidLevelError if i is not a defined value of Level.
+func (i Level) Validate() error {
+	if !_isValid_Level(i) {
+		return &InvalidLevelError{Value: i}
+	}
+	return nil
+}
+`
+
+const clamp_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// Clamp returns i if it is defined, and otherwise the nearest value of Level,
+// preferring the smaller one if two are equally near.
+func (i Level) Clamp() Level {
+	switch {
+	case i < 0:
+		return Low
+	case i <= 1:
+		return i
+	}
+	return High
+}
+`
+
+const predicates_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// IsLow reports whether i is Low.
+func (i Level) IsLow() bool {
+	return i == Low
+}
+
+// IsHigh reports whether i is High.
+func (i Level) IsHigh() bool {
+	return i == High
+}
+`
+
+const enums_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func init() {
+	EnumTypes["Level"] = EnumType{
+		Name:   "Level",
+		Values: []any{Low, High},
+		Names:  LevelNames(),
+		Parse: func(name string) (any, error) {
+			return ParseLevel(name)
+		},
+	}
+}
+`
+
+const runtime_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func init() {
+	enum.Register(reflect.TypeFor[Level](), []enum.Enum{Low, High}, func(name string) (enum.Enum, error) {
+		return ParseLevel(name)
+	})
+}
+`
+
+const wirename_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// WireName returns the name of i for serialization, in kebab case.
+func (i Level) WireName() string {
+	switch i {
+	case Low:
+		return "low"
+	case High:
+		return "high"
+	}
+	return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+`
+
+const debugstring_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _isValid_Level(i Level) bool {
+	switch {
+	case 0 <= i && i <= 1:
+		return true
+	}
+	return false
+}
+
+// DebugString returns the name of i qualified by its type, as in "Level.Low".
+func (i Level) DebugString() string {
+	if !_isValid_Level(i) {
+		return i.String()
+	}
+	return "Level." + i.String()
+}
+`
+
+const fingerprint_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// _Level_hash is the FNV-1a hash of the names and values of Level.
+const _Level_hash = 0x8476432b87d931b8
+
+// LevelFingerprint returns a hash of the names and values of Level, so that
+// programs can check that they agree on its definition.
+func LevelFingerprint() uint64 {
+	return _Level_hash
+}
+`
+
+const options_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelOptions returns all values of Level in ascending order, each with its name
+// as label, for rendering choices such as the options of an HTML select element.
+func LevelOptions() []struct {
+	Value Level
+	Label string
+} {
+	return []struct {
+		Value Level
+		Label string
+	}{
+		{Low, _Level_name[0:3]},
+		{High, _Level_name[3:7]},
+	}
+}
+`
+
+const usage_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// LevelUsage returns the names of all values of Level as "one of: A, B, C",
+// wrapped into lines for usage strings of flags and error messages.
+func LevelUsage() string {
+	return "one of: Low, High"
+}
+`
+
+const templatefuncs_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _isValid_Level(i Level) bool {
+	switch {
+	case 0 <= i && i <= 1:
+		return true
+	}
+	return false
+}
+
+func _suggest_Level(name string) string {
+	a := []rune(strings.ToLower(name))
+	best, bestDist := "", 0
+	for _, n := range [...]string{"Low", "High"} {
+		b := []rune(strings.ToLower(n))
+		// Levenshtein distance, keeping a single row.
+		row := make([]int, len(b)+1)
+		for j := range row {
+			row[j] = j
+		}
+		for i := range a {
+			prev := row[0]
+			row[0] = i + 1
+			for j := range b {
+				cost := 1
+				if a[i] == b[j] {
+					cost = 0
+				}
+				prev, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, prev+cost)
+			}
+		}
+		if d := row[len(b)]; d <= max(2, len(b)/3) && (best == "" || d < bestDist) {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// ErrInvalidLevel is wrapped by the error of ParseLevel for unknown names.
+var ErrInvalidLevel = errors.New("invalid Level")
+
+// ParseLevel returns the Level with the given name.
+func ParseLevel(name string) (Level, error) {
+	i, ok := _lookup_Level(name)
+	if !ok {
+		if s := _suggest_Level(name); s != "" {
+			return 0, fmt.Errorf("%w: %q, did you mean %q?", ErrInvalidLevel, name, s)
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, name)
+	}
+	return i, nil
+}
+
+// LevelValues returns all values of Level in ascending order.
+func LevelValues() []Level {
+	return []Level{Low, High}
+}
+
+// LevelNames returns the names of all values of Level, in the order of LevelValues.
+func LevelNames() []string {
+	return []string{
+		_Level_name[0:3],
+		_Level_name[3:7],
+	}
+}
+
+// LevelTemplateFuncs returns ParseLevel, LevelValues, LevelNames and IsValidLevel
+// as functions of text/template and html/template, as in {{range LevelNames}}.
+func LevelTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"ParseLevel":   ParseLevel,
+		"LevelValues":  LevelValues,
+		"LevelNames":   LevelNames,
+		"IsValidLevel": _isValid_Level,
+	}
+}
+`
+
+const flags_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Read-1]
+	_ = x[Write-2]
+	_ = x[Exec-4]
+	_ = x[All-7]
+}
+
+const _Perm_name = "ReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 9, 13}
+var _Perm_bits = [...]Perm{Read, Write, Exec}
+
+// String returns the names of the flags set in i joined by ", ".
+func (i Perm) String() string {
+	if i == 0 {
+		return "Perm(0)"
+	}
+	var s string
+	for n, bit := range _Perm_bits {
+		if i&bit == 0 {
+			continue
+		}
+		if s != "" {
+			s += ", "
+		}
+		s += _Perm_name[_Perm_index[n]:_Perm_index[n+1]]
+		i &^= bit
+	}
+	if i != 0 {
+		if s != "" {
+			s += ", "
+		}
+		s += "0x" + strconv.FormatUint(uint64(i), 16)
+	}
+	return s
+}
+
+// _PermAll holds the bits of all constants of Perm.
+const _PermAll = Read | Write | Exec | All
+
+// IsSubsetOf reports whether all bits set in i are set in mask.
+func (i Perm) IsSubsetOf(mask Perm) bool {
+	return i&^mask == 0
+}
+
+// Invalid returns the bits set in i which are not part of a constant of Perm.
+func (i Perm) Invalid() Perm {
+	return i &^ _PermAll
+}
+`
+
+const flagparse_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Read-1]
+	_ = x[Write-2]
+	_ = x[Exec-4]
+	_ = x[All-7]
+}
+
+func _lookupFlag_Perm(name string) (Perm, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0x45eae17c:
+		if name == "Exec" {
+			return Exec, true
+		}
+	case 0x4f0befe5:
+		if name == "Read" {
+			return Read, true
+		}
+	case 0x665c79fc:
+		if name == "Write" {
+			return Write, true
+		}
+	case 0x75afe364:
+		if name == "All" {
+			return All, true
+		}
+	}
+	return 0, false
+}
+
+func _lookupFlags_Perm(name string) (Perm, string, bool) {
+	var i Perm
+	for token := range strings.SplitSeq(name, "|") {
+		token = strings.TrimSpace(token)
+		flag, ok := _lookupFlag_Perm(token)
+		if !ok {
+			return flag, token, false
+		}
+		i |= flag
+	}
+	return i, "", true
+}
+
+func _lookup_Perm(name string) (Perm, bool) {
+	i, _, ok := _lookupFlags_Perm(name)
+	return i, ok
+}
+
+const _Perm_name = "ReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 9, 13}
+var _Perm_bits = [...]Perm{Read, Write, Exec}
+
+// String returns the names of the flags set in i joined by "|".
+func (i Perm) String() string {
+	if i == 0 {
+		return "Perm(0)"
+	}
+	var s string
+	for n, bit := range _Perm_bits {
+		if i&bit == 0 {
+			continue
+		}
+		if s != "" {
+			s += "|"
+		}
+		s += _Perm_name[_Perm_index[n]:_Perm_index[n+1]]
+		i &^= bit
+	}
+	if i != 0 {
+		if s != "" {
+			s += "|"
+		}
+		s += "0x" + strconv.FormatUint(uint64(i), 16)
+	}
+	return s
+}
+
+// _PermAll holds the bits of all constants of Perm.
+const _PermAll = Read | Write | Exec | All
+
+// IsSubsetOf reports whether all bits set in i are set in mask.
+func (i Perm) IsSubsetOf(mask Perm) bool {
+	return i&^mask == 0
+}
+
+// Invalid returns the bits set in i which are not part of a constant of Perm.
+func (i Perm) Invalid() Perm {
+	return i &^ _PermAll
+}
+
+func _suggest_Perm(name string) string {
+	a := []rune(strings.ToLower(name))
+	best, bestDist := "", 0
+	for _, n := range [...]string{"Read", "Write", "Exec", "All"} {
+		b := []rune(strings.ToLower(n))
+		// Levenshtein distance, keeping a single row.
+		row := make([]int, len(b)+1)
+		for j := range row {
+			row[j] = j
+		}
+		for i := range a {
+			prev := row[0]
+			row[0] = i + 1
+			for j := range b {
+				cost := 1
+				if a[i] == b[j] {
+					cost = 0
+				}
+				prev, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, prev+cost)
+			}
+		}
+		if d := row[len(b)]; d <= max(2, len(b)/3) && (best == "" || d < bestDist) {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// ErrInvalidPerm is wrapped by the error of ParsePerm for unknown names.
+var ErrInvalidPerm = errors.New("invalid Perm")
+
+// ParsePerm returns the combination of the flags named in name, separated by "|".
+// The error names the first unknown flag.
+func ParsePerm(name string) (Perm, error) {
+	var i Perm
+	for token := range strings.SplitSeq(name, "|") {
+		flag, err := _parseFlag_Perm(strings.TrimSpace(token))
+		if err != nil {
+			return 0, err
+		}
+		i |= flag
+	}
+	return i, nil
+}
+
+func _parseFlag_Perm(name string) (Perm, error) {
+	i, ok := _lookupFlag_Perm(name)
+	if !ok {
+		if s := _suggest_Perm(name); s != "" {
+			return 0, fmt.Errorf("%w: %q, did you mean %q?", ErrInvalidPerm, name, s)
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPerm, name)
+	}
+	return i, nil
+}
+`
+
+const flagiter_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Read-1]
+	_ = x[Write-2]
+	_ = x[Exec-4]
+	_ = x[All-7]
+}
+
+const _Perm_name = "ReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 9, 13}
+var _Perm_bits = [...]Perm{Read, Write, Exec}
+
+// String returns the names of the flags set in i joined by "|".
+func (i Perm) String() string {
+	if i == 0 {
+		return "Perm(0)"
+	}
+	var s string
+	for n, bit := range _Perm_bits {
+		if i&bit == 0 {
+			continue
+		}
+		if s != "" {
+			s += "|"
+		}
+		s += _Perm_name[_Perm_index[n]:_Perm_index[n+1]]
+		i &^= bit
+	}
+	if i != 0 {
+		if s != "" {
+			s += "|"
+		}
+		s += "0x" + strconv.FormatUint(uint64(i), 16)
+	}
+	return s
+}
+
+// _PermAll holds the bits of all constants of Perm.
+const _PermAll = Read | Write | Exec | All
+
+// IsSubsetOf reports whether all bits set in i are set in mask.
+func (i Perm) IsSubsetOf(mask Perm) bool {
+	return i&^mask == 0
+}
+
+// Invalid returns the bits set in i which are not part of a constant of Perm.
+func (i Perm) Invalid() Perm {
+	return i &^ _PermAll
+}
+
+// PermAll returns an iterator over all values of Perm in ascending order.
+func PermAll() iter.Seq[Perm] {
+	return func(yield func(Perm) bool) {
+		for _, i := range [...]Perm{Read, Write, Exec, All} {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Flags returns an iterator over the flags set in i in the order of their
+// declaration, followed by the bits without a name in ascending order.
+func (i Perm) Flags() iter.Seq[Perm] {
+	return func(yield func(Perm) bool) {
+		for _, bit := range [...]Perm{Read, Write, Exec} {
+			if i&bit == 0 {
+				continue
+			}
+			if !yield(bit) {
+				return
+			}
+			i &^= bit
+		}
+		for i != 0 {
+			bit := i & -i // The lowest set bit.
+			if !yield(bit) {
+				return
+			}
+			i &^= bit
+		}
+	}
+}
+`
+
+const flagjson_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Read-1]
+	_ = x[Write-2]
+	_ = x[Exec-4]
+	_ = x[All-7]
+}
+
+func _lookupFlag_Perm(name string) (Perm, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0x45eae17c:
+		if name == "Exec" {
+			return Exec, true
+		}
+	case 0x4f0befe5:
+		if name == "Read" {
+			return Read, true
+		}
+	case 0x665c79fc:
+		if name == "Write" {
+			return Write, true
+		}
+	case 0x75afe364:
+		if name == "All" {
+			return All, true
+		}
+	}
+	return 0, false
+}
+
+func _lookupFlags_Perm(name string) (Perm, string, bool) {
+	var i Perm
+	for token := range strings.SplitSeq(name, "|") {
+		token = strings.TrimSpace(token)
+		flag, ok := _lookupFlag_Perm(token)
+		if !ok {
+			return flag, token, false
+		}
+		i |= flag
+	}
+	return i, "", true
+}
+
+func _lookup_Perm(name string) (Perm, bool) {
+	i, _, ok := _lookupFlags_Perm(name)
+	return i, ok
+}
+
+const _Perm_name = "ReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 9, 13}
+var _Perm_bits = [...]Perm{Read, Write, Exec}
+
+// String returns the names of the flags set in i joined by "|".
+func (i Perm) String() string {
+	if i == 0 {
+		return "Perm(0)"
+	}
+	var s string
+	for n, bit := range _Perm_bits {
+		if i&bit == 0 {
+			continue
+		}
+		if s != "" {
+			s += "|"
+		}
+		s += _Perm_name[_Perm_index[n]:_Perm_index[n+1]]
+		i &^= bit
+	}
+	if i != 0 {
+		if s != "" {
+			s += "|"
+		}
+		s += "0x" + strconv.FormatUint(uint64(i), 16)
+	}
+	return s
+}
+
+// _PermAll holds the bits of all constants of Perm.
+const _PermAll = Read | Write | Exec | All
+
+// IsSubsetOf reports whether all bits set in i are set in mask.
+func (i Perm) IsSubsetOf(mask Perm) bool {
+	return i&^mask == 0
+}
+
+// Invalid returns the bits set in i which are not part of a constant of Perm.
+func (i Perm) Invalid() Perm {
+	return i &^ _PermAll
+}
+
+func (i Perm) MarshalJSON() ([]byte, error) {
+	flags := []any{}
+	for n, bit := range _Perm_bits {
+		if i&bit != 0 {
+			flags = append(flags, _Perm_name[_Perm_index[n]:_Perm_index[n+1]])
+			i &^= bit
+		}
+	}
+	if i != 0 {
+		flags = append(flags, uint64(i))
+	}
+	return json.Marshal(flags)
+}
+
+func (i *Perm) UnmarshalJSON(b []byte) error {
+	var value any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case string:
+		m, ok := _lookup_Perm(v)
+		if !ok {
+			return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(Perm(0))}
+		}
+		*i = m
+	case []any:
+		var m Perm
+		for _, flag := range v {
+			switch flag := flag.(type) {
+			case string:
+				n, ok := _lookup_Perm(flag)
+				if !ok {
+					return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(Perm(0))}
+				}
+				m |= n
+			case json.Number:
+				n, err := strconv.ParseUint(string(flag), 10, 64)
+				if err != nil {
+					return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(Perm(0))}
+				}
+				m |= Perm(n)
+			default:
+				return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(Perm(0))}
+			}
+		}
+		*i = m
+	case json.Number:
+		n, err := strconv.ParseUint(string(v), 10, 64)
+		if err != nil {
+			return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(Perm(0))}
+		}
+		*i = Perm(n)
+	default:
+		return &json.UnmarshalTypeError{Value: string(b), Type: reflect.TypeOf(Perm(0))}
+	}
+	return nil
+}
+`
+
+const flagdecimal_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Read-1]
+	_ = x[Write-2]
+	_ = x[Exec-4]
+	_ = x[All-7]
+}
+
+const _Perm_name = "ReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 9, 13}
+var _Perm_bits = [...]Perm{Read, Write, Exec}
+
+// String returns the names of the flags set in i joined by "|".
+func (i Perm) String() string {
+	if i == 0 {
+		return "Perm(0)"
+	}
+	var s string
+	for n, bit := range _Perm_bits {
+		if i&bit == 0 {
+			continue
+		}
+		if s != "" {
+			s += "|"
+		}
+		s += _Perm_name[_Perm_index[n]:_Perm_index[n+1]]
+		i &^= bit
+	}
+	if i != 0 {
+		if s != "" {
+			s += "|"
+		}
+		s += strconv.FormatUint(uint64(i), 10)
+	}
+	return s
+}
+
+// _PermAll holds the bits of all constants of Perm.
+const _PermAll = Read | Write | Exec | All
+
+// IsSubsetOf reports whether all bits set in i are set in mask.
+func (i Perm) IsSubsetOf(mask Perm) bool {
+	return i&^mask == 0
+}
+
+// Invalid returns the bits set in i which are not part of a constant of Perm.
+func (i Perm) Invalid() Perm {
+	return i &^ _PermAll
+}
+`
+
+const declorder_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Medium-1]
+	_ = x[Low-0]
+	_ = x[High-2]
+}
+
+const _Priority_name = "LowMediumHigh"
+
+var _Priority_index = [...]uint8{0, 3, 9, 13}
+
+func (i Priority) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Priority_index)-1 {
+		return "Priority(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Priority_name[_Priority_index[idx]:_Priority_index[idx+1]]
+}
+
+// PriorityValues returns all values of Priority in the order of their declaration.
+func PriorityValues() []Priority {
+	return []Priority{Medium, Low, High}
+}
+
+// PriorityNames returns the names of all values of Priority, in the order of PriorityValues.
+func PriorityNames() []string {
+	return []string{
+		_Priority_name[3:9],
+		_Priority_name[0:3],
+		_Priority_name[9:13],
+	}
+}
+`
+
+const i18n_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+var _Level_i18n = map[string]map[Level]string{
+	"de": {
+		Low:  "Niedrig",
+		High: "Hoch",
+	},
+	"fr": {
+		High: "Haut",
+	},
+}
+
+// StringIn returns the name of i in the locale lang, such as "de" or "pt-BR".
+// Locales without a name of i fall back to their base language and then to String.
+func (i Level) StringIn(lang string) string {
+	for {
+		if name, ok := _Level_i18n[lang][i]; ok {
+			return name
+		}
+		n := strings.LastIndexAny(lang, "-_")
+		if n < 0 {
+			return i.String()
+		}
+		lang = lang[:n]
+	}
+}
+`
+
+const message_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+// MessageKey returns the key of i for golang.org/x/text/message, as in
+// p.Sprintf(i.MessageKey()), falling back to String without a translation.
+func (i Level) MessageKey() message.Reference {
+	return message.Key("Level."+i.String(), strings.ReplaceAll(i.String(), "%", "%%"))
+}
+
+// LevelSetMessages sets the names of all values of Level in the catalog for tag,
+// under the keys returned by MessageKey.
+func LevelSetMessages(b *catalog.Builder, tag language.Tag) error {
+	for _, i := range [...]Level{Low, High} {
+		if err := b.SetString(tag, "Level."+i.String(), strings.ReplaceAll(i.String(), "%", "%%")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+`
+
+const label_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Active-0]
+	_ = x[Stopped-1]
+	_ = x[Unknown-2]
+}
+
+const _Status_name = "ActiveStoppedUnknown"
+
+var _Status_index = [...]uint8{0, 6, 13, 20}
+
+func (i Status) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Status_index)-1 {
+		return "Status(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Status_name[_Status_index[idx]:_Status_index[idx+1]]
+}
+
+var _Status_labels = map[Status]map[string]string{
+	Active:  {"de": "Aktiv", "en": "Active"},
+	Stopped: {"de": "Angehalten", "de-CH": "Gestoppt", "en": "Stopped"},
+}
+
+// Label returns the name of i in the language tag, as declared by a
+// "//morestringer:label" directive. Tags without a name of i fall back
+// to their parents, as de-CH to de, and then to String.
+func (i Status) Label(tag language.Tag) string {
+	labels := _Status_labels[i]
+	for {
+		if label, ok := labels[tag.String()]; ok {
+			return label
+		}
+		if tag.IsRoot() {
+			return i.String()
+		}
+		tag = tag.Parent()
+	}
+}
+`
+
+const strconst_out = `func _() {
+	// A "duplicate key" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	_ = map[bool]int{false: 0, Red == "red": 0}
+	_ = map[bool]int{false: 0, Green == "green": 0}
+	_ = map[bool]int{false: 0, Scarlet == "red": 0}
+}
+
+func _lookup_Color(name string) (Color, bool) {
+	switch name {
+	case "red":
+		return Red, true
+	case "green":
+		return Green, true
+	case "Red":
+		return Red, true
+	case "Green":
+		return Green, true
+	case "Scarlet":
+		return Scarlet, true
+	}
+	return "", false
+}
+
+func (i Color) String() string {
+	switch i {
+	case Red:
+		return "red"
+	case Green:
+		return "green"
+	}
+	return "Color(" + strconv.Quote(string(i)) + ")"
+}
+
+func _suggest_Color(name string) string {
+	a := []rune(strings.ToLower(name))
+	best, bestDist := "", 0
+	for _, n := range [...]string{"red", "green", "red"} {
+		b := []rune(strings.ToLower(n))
+		// Levenshtein distance, keeping a single row.
+		row := make([]int, len(b)+1)
+		for j := range row {
+			row[j] = j
+		}
+		for i := range a {
+			prev := row[0]
+			row[0] = i + 1
+			for j := range b {
+				cost := 1
+				if a[i] == b[j] {
+					cost = 0
+				}
+				prev, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, prev+cost)
+			}
+		}
+		if d := row[len(b)]; d <= max(2, len(b)/3) && (best == "" || d < bestDist) {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// ErrInvalidColor is wrapped by the error of ParseColor for unknown names.
+var ErrInvalidColor = errors.New("invalid Color")
+
+// ParseColor returns the Color with the given name.
+func ParseColor(name string) (Color, error) {
+	i, ok := _lookup_Color(name)
+	if !ok {
+		if s := _suggest_Color(name); s != "" {
+			return "", fmt.Errorf("%w: %q, did you mean %q?", ErrInvalidColor, name, s)
+		}
+		return "", fmt.Errorf("%w: %q", ErrInvalidColor, name)
+	}
+	return i, nil
+}
+`
+
+const frompkg_out = `
+// Level is levels.Level, declared here to have the generated methods.
+type Level levels.Level
+
+const (
+	Low  = Level(levels.Low)
+	High = Level(levels.High)
+)
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+`
+
+const slice_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Low-0]
+	_ = x[High-1]
+}
+
+func _lookup_Level(name string) (Level, bool) {
+	// fnv1a32 hash
+	var h uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		h ^= uint32(name[i])
+		h *= 16777619
+	}
+
+	switch h {
+	case 0xb1dd9521:
+		if name == "Low" {
+			return Low, true
+		}
+	case 0xbe0a061d:
+		if name == "High" {
+			return High, true
+		}
+	}
+	return 0, false
+}
+
+const _Level_name = "LowHigh"
+
+var _Level_index = [...]uint8{0, 3, 7}
+
+func (i Level) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Level_index)-1 {
+		return "Level(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Level_name[_Level_index[idx]:_Level_index[idx+1]]
+}
+
+func _suggest_Level(name string) string {
+	a := []rune(strings.ToLower(name))
+	best, bestDist := "", 0
+	for _, n := range [...]string{"Low", "High"} {
+		b := []rune(strings.ToLower(n))
+		// Levenshtein distance, keeping a single row.
+		row := make([]int, len(b)+1)
+		for j := range row {
+			row[j] = j
+		}
+		for i := range a {
+			prev := row[0]
+			row[0] = i + 1
+			for j := range b {
+				cost := 1
+				if a[i] == b[j] {
+					cost = 0
+				}
+				prev, row[j+1] = row[j+1], min(row[j+1]+1, row[j]+1, prev+cost)
+			}
+		}
+		if d := row[len(b)]; d <= max(2, len(b)/3) && (best == "" || d < bestDist) {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+// ErrInvalidLevel is wrapped by the error of ParseLevel for unknown names.
+var ErrInvalidLevel = errors.New("invalid Level")
+
+// ParseLevel returns the Level with the given name.
+func ParseLevel(name string) (Level, error) {
+	i, ok := _lookup_Level(name)
+	if !ok {
+		if s := _suggest_Level(name); s != "" {
+			return 0, fmt.Errorf("%w: %q, did you mean %q?", ErrInvalidLevel, name, s)
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLevel, name)
+	}
+	return i, nil
+}
+
+// ParseLevelSlice parses each of the names, failing on the first unknown name.
+func ParseLevelSlice(names []string) ([]Level, error) {
+	values := make([]Level, len(names))
+	for n, name := range names {
+		i, err := ParseLevel(name)
+		if err != nil {
+			return nil, err
+		}
+		values[n] = i
+	}
+	return values, nil
+}
+
+// LevelNames returns the names of all values of Level in ascending order, joined by sep.
+func LevelNames(sep string) string {
+	return strings.Join([]string{"Low", "High"}, sep)
+}
+`
+
+func TestGoldenOptions(t *testing.T) {
+	for _, test := range goldenOptions {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := memPackage("package test\n"+test.input, naming{})
+
+			// Extract the name and type of the constant from the first line.
+			tokens := strings.SplitN(test.input, " ", 3)
+			if len(tokens) != 3 {
+				t.Fatalf("%s: need type declaration on first line", test.name)
+			}
+
+			g := test.gen
+			g.genType(tokens[1], pkg.findValues(tokens[1])[tokens[1]])
+			got := string(g.format())
+			if got != test.output {
+				t.Errorf("%s does not have the expected content:\n%s", test.name, diffp.Diff("want", []byte(test.output), "got", []byte(got)))
+			}
+		})
+	}
+}
+
+// combinedOptions are generators with options generating declarations
+// which could clash.
+var combinedOptions = []struct {
+	name string
+	gen  Generator
+}{
+	{"msgpack validate", Generator{msgpack: true, validate: true}},
+	{"prometheus label", Generator{prometheus: true, label: true}},
+}
+
+// TestCombinedOptions verifies that no declaration is generated twice when
+// options are combined.
+func TestCombinedOptions(t *testing.T) {
+	for _, test := range combinedOptions {
+		t.Run(test.name, func(t *testing.T) {
+			pkg := memPackage("package test\n"+level_in, naming{})
+			g := test.gen
+			g.genType("Level", pkg.findValues("Level")["Level"])
+			file, err := parser.ParseFile(token.NewFileSet(), "", "package test\n"+string(g.format()), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen := make(map[string]bool)
+			for _, decl := range file.Decls {
+				var names []string
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					name := decl.Name.Name
+					if decl.Recv != nil {
+						recv := decl.Recv.List[0].Type
+						if star, ok := recv.(*ast.StarExpr); ok {
+							recv = star.X
+						}
+						name = recv.(*ast.Ident).Name + "." + name
+					}
+					names = append(names, name)
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						switch spec := spec.(type) {
+						case *ast.TypeSpec:
+							names = append(names, spec.Name.Name)
+						case *ast.ValueSpec:
+							for _, name := range spec.Names {
+								names = append(names, name.Name)
+							}
+						}
+					}
+				}
+				for _, name := range names {
+					if name == "_" || name == "init" {
+						continue
+					}
+					if seen[name] {
+						t.Errorf("%s is declared twice", name)
+					}
+					seen[name] = true
+				}
+			}
+		})
+	}
+}
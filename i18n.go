@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// catalog holds the names of a locale, by the name of the type and the
+// name printed by String.
+type catalog map[string]map[string]string
+
+// readCatalogs reads the catalogs in dir, one file "<locale>.json" per
+// locale, each mapping types to their names and the translated names:
+//
+//	{"Status": {"Active": "Aktiv", "Stopped": "Angehalten"}}
+func readCatalogs(dir string) (map[string]catalog, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no catalogs in %s", dir)
+	}
+	catalogs := make(map[string]catalog)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+		catalogs[strings.TrimSuffix(filepath.Base(path), ".json")] = c
+	}
+	return catalogs, nil
+}
+
+// locales returns the locales of the catalogs in sorted order.
+func locales(catalogs map[string]catalog) []string {
+	return slices.Sorted(maps.Keys(catalogs))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// lockName is the name of the file recording the names and values of
+// the generated types, in the directory of the output.
+const lockName = "enum.lock"
+
+// lockedValue is a name of a type and its value as recorded in the lock file.
+type lockedValue struct {
+	name  string
+	value string
+}
+
+// lockValues returns the names and values of a type to record in the
+// lock file, ordered by value and name. The names are those printed by
+// String, as they are what ends up being persisted.
+func lockValues(values []Value) []lockedValue {
+	values = slices.Clone(values)
+	slices.SortStableFunc(values, func(a, b Value) int {
+		return cmp.Compare(a.value, b.value)
+	})
+	locked := make([]lockedValue, 0, len(values))
+	for _, v := range values {
+		lv := lockedValue{v.repr, v.str}
+		if !slices.Contains(locked, lv) {
+			locked = append(locked, lv)
+		}
+	}
+	return locked
+}
+
+// readLock reads the lock file, each line holding a type, a quoted name
+// and its value. A missing file is an empty lock.
+func readLock(path string) (map[string][]lockedValue, error) {
+	lock := make(map[string][]lockedValue)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		typeName, rest, _ := strings.Cut(line, " ")
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid name", path, n)
+		}
+		name, _ := strconv.Unquote(quoted)
+		value := strings.TrimSpace(rest[len(quoted):])
+		if typeName == "" || value == "" || strings.Contains(value, " ") {
+			return nil, fmt.Errorf("%s:%d: expected type, name and value", path, n)
+		}
+		lock[typeName] = append(lock[typeName], lockedValue{name, value})
+	}
+	return lock, s.Err()
+}
+
+// writeLock writes the lock file, ordered by the name of the types.
+func writeLock(path string, lock map[string][]lockedValue) error {
+	var b bytes.Buffer
+	b.WriteString("# Code generated by morestringer; DO NOT EDIT.\n")
+	b.WriteString("# The names and values of the generated types, checked by -compat-check.\n")
+	types := make([]string, 0, len(lock))
+	for typeName := range lock {
+		types = append(types, typeName)
+	}
+	slices.Sort(types)
+	for _, typeName := range types {
+		for _, v := range lock[typeName] {
+			fmt.Fprintf(&b, "%s %q %s\n", typeName, v.name, v.value)
+		}
+	}
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
+
+// checkCompat returns an error describing the first name recorded in
+// locked which has been removed or whose value has changed in values.
+// Names added to the type are compatible.
+func checkCompat(typeName string, locked, values []lockedValue) error {
+	current := make(map[string][]string)
+	for _, v := range values {
+		current[v.name] = append(current[v.name], v.value)
+	}
+	for _, v := range locked {
+		now, ok := current[v.name]
+		switch {
+		case !ok:
+			return fmt.Errorf("%s: name %q with value %s was removed", typeName, v.name, v.value)
+		case !slices.Contains(now, v.value):
+			return fmt.Errorf("%s: value of name %q changed from %s to %s", typeName, v.name, v.value, strings.Join(now, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// maxPerfectSeed bounds the search for the seed of a bucket, so names
+// which cannot be separated, such as duplicates, make the search fail
+// instead of running forever.
+const maxPerfectSeed = 1 << 16
+
+// perfectHash computes a minimal perfect hash of the names using hash and
+// displace: names are distributed into buckets by perfectHash32 with seed 0,
+// then for each bucket, largest first, a seed is searched which moves all of
+// its names into free slots. It returns the seed of each bucket and the slot
+// of each name; ok is false if no perfect hash was found.
+func perfectHash(names []string) (seeds []uint32, slots []int, ok bool) {
+	n := uint32(len(names))
+	buckets := make([][]int, n)
+	for i, name := range names {
+		b := perfectHash32(0, name) % n
+		buckets[b] = append(buckets[b], i)
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(left, right int) int {
+		return cmp.Compare(len(buckets[right]), len(buckets[left]))
+	})
+
+	seeds = make([]uint32, n)
+	slots = make([]int, n)
+	used := make([]bool, n)
+	var taken []int
+	for _, b := range order {
+		if len(buckets[b]) == 0 {
+			break
+		}
+	search:
+		for seed := uint32(1); ; seed++ {
+			if seed > maxPerfectSeed {
+				return nil, nil, false
+			}
+			taken = taken[:0]
+			for _, i := range buckets[b] {
+				slot := int(perfectHash32(seed, names[i]) % n)
+				if used[slot] || slices.Contains(taken, slot) {
+					continue search
+				}
+				taken = append(taken, slot)
+			}
+			for k, i := range buckets[b] {
+				slots[i] = taken[k]
+				used[taken[k]] = true
+			}
+			seeds[b] = seed
+			break
+		}
+	}
+	return seeds, slots, true
+}
+
+// perfectHash32 is the seeded fnv1a32 hash used by the perfect hash lookup,
+// with a final shift improving the distribution of the lower bits.
+func perfectHash32(seed uint32, s string) uint32 {
+	h := 2166136261 ^ seed
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h ^ h>>16
+}
@@ -6,6 +6,8 @@ package main
 
 import (
 	"cmp"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"go/ast"
@@ -16,9 +18,15 @@ import (
 	"go/types"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -40,56 +48,168 @@ func baseName(pkg *Package, typename string) string {
 }
 
 // isDirectory reports whether the named file is a directory.
-func isDirectory(name string) bool {
+func isDirectory(name string) (bool, error) {
 	info, err := os.Stat(name)
 	if err != nil {
-		log.Fatal(err)
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// modeFlag is a boolean flag which optionally selects one of several modes,
+// as in -bson or -bson=number. The first mode is used when no mode is given.
+type modeFlag struct {
+	mode  string
+	modes []string
+}
+
+func newModeFlag(name, usage string, modes ...string) *string {
+	f := &modeFlag{modes: modes}
+	flag.Var(f, name, fmt.Sprintf("%s; `mode` is one of %s", usage, strings.Join(modes, ", ")))
+	return &f.mode
+}
+
+func (f *modeFlag) String() string {
+	return f.mode
+}
+
+func (f *modeFlag) Set(s string) error {
+	switch s {
+	case "true":
+		f.mode = f.modes[0]
+	case "false":
+		f.mode = ""
+	default:
+		if !slices.Contains(f.modes, s) {
+			return fmt.Errorf("unknown mode %q, want one of %s", s, strings.Join(f.modes, ", "))
+		}
+		f.mode = s
 	}
-	return info.IsDir()
+	return nil
+}
+
+func (f *modeFlag) IsBoolFlag() bool {
+	return true
+}
+
+// lookupSig describes the shape of the lookup function given by -lookup as
+// "[Recv.]Name[(string|[]byte)][ bool|error]".
+type lookupSig struct {
+	recv   string // Receiver type making the lookup a method, like "*Registry".
+	name   string // Function name, "{}" is replaced with the type.
+	param  string // "string" or "[]byte".
+	result string // "bool" or "error".
+}
+
+var lookupSigRe = regexp.MustCompile(`^(?:(\*?\w+)\.)?([\w{}]+)(?:\((string|\[\]byte)\))?(?: +(bool|error))?$`)
+
+func parseLookupSig(s string) (lookupSig, error) {
+	m := lookupSigRe.FindStringSubmatch(s)
+	if m == nil {
+		return lookupSig{}, fmt.Errorf("invalid -lookup=%s, want [Recv.]Name[(string|[]byte)][ bool|error]", s)
+	}
+	sig := lookupSig{recv: m[1], name: m[2], param: cmp.Or(m[3], "string"), result: cmp.Or(m[4], "bool")}
+	return sig, nil
+}
+
+// plain reports whether the lookup has the shape used by the generated
+// methods, so no wrapper is needed.
+func (sig lookupSig) plain() bool {
+	return sig.recv == "" && sig.param == "string" && sig.result == "bool"
 }
 
 type Package struct {
 	name         string
+	types        *types.Package
 	defs         map[*ast.Ident]types.Object
 	files        []*ast.File
 	hasTestFiles bool
 
+	naming
+	typeNaming map[string]naming // Overrides of naming by the type name, given by -type.
+
+	sentinels map[string][]sentinel // Constants counting the values, by the type.
+}
+
+// sentinel is a constant counting or bounding the values of a type, like
+// statusCount for the type Status.
+type sentinel struct {
+	name  string
+	value constant.Value
+}
+
+// naming holds the flags deriving the printed names from the constants.
+type naming struct {
 	trimPrefix  string
+	trimSuffix  string
+	addPrefix   string
+	addSuffix   string
 	lineComment bool
 	cNames      bool
+	runes       bool // Name constants declared by character literals by the quoted rune.
+
+	nameTemplate *template.Template // Derives the names instead of trimming, given by -nametemplate.
+}
+
+// nameData is the data of the template given by -nametemplate.
+type nameData struct {
+	Name    string // The name of the constant.
+	Trimmed string // The name without the trimmed prefixes and suffixes.
+	Type    string // The name of the type.
+	Value   string // The value of the constant.
+	Comment string // The line comment.
+}
+
+// nameFuncs returns the functions available to the template given by
+// -nametemplate, converting cases with the acronyms kept intact.
+func nameFuncs(acronyms []string) template.FuncMap {
+	return template.FuncMap{
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"snake":      func(s string) string { return snakeCase(s, acronyms) },
+		"kebab":      func(s string) string { return kebabCase(s, acronyms) },
+		"screaming":  func(s string) string { return screamingSnakeCase(s, acronyms) },
+	}
+}
+
+// parseNameTemplate parses the template given by -nametemplate.
+func parseNameTemplate(text string, acronyms []string) (*template.Template, error) {
+	return template.New("nametemplate").Funcs(nameFuncs(acronyms)).Option("missingkey=error").Parse(text)
 }
 
-// loadPackages analyzes the single package constructed from the patterns and tags.
-// loadPackages exits if there is an error.
+// loadPackages analyzes the single package constructed from the patterns and tags,
+// resolved in dir. Loading stops with an error when ctx is cancelled.
 //
 // Returns all variants (such as tests) of the package.
-//
-// logf is a test logging hook. It can be nil when not testing.
-func loadPackages(patterns, tags []string, trimPrefix string, lineComment, cNames bool) []*Package {
+func loadPackages(ctx context.Context, dir string, patterns, tags []string, naming naming, typeNaming map[string]naming) ([]*Package, error) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedFiles,
+		Context: ctx,
+		Dir:     dir,
+		Mode:    packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedFiles,
 		// Tests are included, let the caller decide how to fold them in.
 		Tests:      true,
 		BuildFlags: []string{fmt.Sprintf("-tags=%s", strings.Join(tags, " "))},
 	}
 	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	if len(pkgs) == 0 {
-		log.Fatalf("error: no packages matching %v", strings.Join(patterns, " "))
+		return nil, fmt.Errorf("error: no packages matching %v", strings.Join(patterns, " "))
 	}
 
 	out := make([]*Package, len(pkgs))
 	for i, pkg := range pkgs {
 		p := &Package{
 			name:  pkg.Name,
+			types: pkg.Types,
 			defs:  pkg.TypesInfo.Defs,
 			files: pkg.Syntax,
 
-			trimPrefix:  trimPrefix,
-			lineComment: lineComment,
-			cNames:      cNames,
+			naming:     naming,
+			typeNaming: typeNaming,
 		}
 
 		// Keep track of test files, since we might want to generated
@@ -104,10 +224,10 @@ func loadPackages(patterns, tags []string, trimPrefix string, lineComment, cName
 
 		out[i] = p
 	}
-	return out
+	return out, nil
 }
 
-func memPackage(source string, trimPrefix string, lineComment, cNames bool) *Package {
+func memPackage(source string, naming naming) *Package {
 	fset := token.NewFileSet()
 	fileast, err := parser.ParseFile(fset, "testsource.go", source, parser.ParseComments)
 	if err != nil {
@@ -124,18 +244,50 @@ func memPackage(source string, trimPrefix string, lineComment, cNames bool) *Pac
 	}
 
 	p := &Package{
-		name: pkg.Name(),
-		defs: info.Defs,
+		name:  pkg.Name(),
+		types: pkg,
+		defs:  info.Defs,
 
-		trimPrefix:  trimPrefix,
-		lineComment: lineComment,
-		cNames:      cNames,
-		files:       []*ast.File{fileast},
+		naming: naming,
+		files:  []*ast.File{fileast},
 	}
 
 	return p
 }
 
+// protoEnum resolves the enum type referenced as "pkg.Name" among the imports
+// of the package. It returns the import path and the names of the constants
+// of the enum by value.
+func (pkg *Package) protoEnum(ref string) (string, map[uint64]string, error) {
+	pkgName, typeName, ok := strings.Cut(ref, ".")
+	if !ok {
+		return "", nil, fmt.Errorf("invalid -proto=%s, want pkg.EnumName", ref)
+	}
+	for _, imp := range pkg.types.Imports() {
+		if imp.Name() != pkgName {
+			continue
+		}
+		obj, ok := imp.Scope().Lookup(typeName).(*types.TypeName)
+		if !ok {
+			return "", nil, fmt.Errorf("no type %s in package %s", typeName, imp.Path())
+		}
+		consts := make(map[uint64]string)
+		for _, name := range imp.Scope().Names() {
+			c, ok := imp.Scope().Lookup(name).(*types.Const)
+			if !ok || !types.Identical(c.Type(), obj.Type()) {
+				continue
+			}
+			if v, ok := constant.Int64Val(c.Val()); ok {
+				if _, dup := consts[uint64(v)]; !dup {
+					consts[uint64(v)] = name
+				}
+			}
+		}
+		return imp.Path(), consts, nil
+	}
+	return "", nil, fmt.Errorf("package %s of -proto=%s is not imported by %s", pkgName, ref, pkg.name)
+}
+
 func (pkg *Package) findValues(typeNames ...string) map[string][]Value {
 	typeValues := make(map[string][]Value, len(typeNames))
 	for _, name := range typeNames {
@@ -157,18 +309,102 @@ func (pkg *Package) findValues(typeNames ...string) map[string][]Value {
 	return typeValues
 }
 
+// valueRange is a range of values given by a type-level directive
+// "//morestringer:range Name Lo..Hi". The bounds are numbers or names
+// of constants.
+type valueRange struct {
+	name   string
+	lo, hi string
+}
+
+// findRanges returns the ranges declared in the doc comments of the named types.
+func (pkg *Package) findRanges(typeNames ...string) map[string][]valueRange {
+	ranges := make(map[string][]valueRange)
+	for _, file := range pkg.files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				tspec := spec.(*ast.TypeSpec) // Guaranteed to succeed as this is TYPE.
+				if !slices.Contains(typeNames, tspec.Name.Name) {
+					continue
+				}
+				doc := tspec.Doc
+				if doc == nil && !decl.Lparen.IsValid() {
+					doc = decl.Doc
+				}
+				for _, arg := range directives(doc, "range") {
+					r, err := parseRange(arg)
+					if err != nil {
+						failf("%s: %s", tspec.Name.Name, err)
+					}
+					ranges[tspec.Name.Name] = append(ranges[tspec.Name.Name], r)
+				}
+			}
+		}
+	}
+	return ranges
+}
+
+// parseRange parses the argument of a range directive, "Name Lo..Hi".
+func parseRange(arg string) (valueRange, error) {
+	fields := strings.Fields(arg)
+	if len(fields) != 2 || !token.IsIdentifier(fields[0]) {
+		return valueRange{}, fmt.Errorf("invalid range %q, want Name Lo..Hi", arg)
+	}
+	lo, hi, ok := strings.Cut(fields[1], "..")
+	if !ok || lo == "" || hi == "" {
+		return valueRange{}, fmt.Errorf("invalid range %q, want Name Lo..Hi", arg)
+	}
+	return valueRange{name: fields[0], lo: lo, hi: hi}, nil
+}
+
+// parseLabels parses the argument of a label directive, a list of
+// language tags and quoted names as in `en="Active" de="Aktiv"`.
+func parseLabels(arg string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for arg = strings.TrimSpace(arg); arg != ""; arg = strings.TrimSpace(arg) {
+		tag, rest, ok := strings.Cut(arg, "=")
+		quoted, err := strconv.QuotedPrefix(rest)
+		if !ok || tag == "" || strings.ContainsAny(tag, " \t") || err != nil {
+			return nil, fmt.Errorf("invalid label %q, want tag=\"name\"", arg)
+		}
+		if _, dup := labels[tag]; dup {
+			return nil, fmt.Errorf("label %s given more than once", tag)
+		}
+		labels[tag], _ = strconv.Unquote(quoted)
+		arg = rest[len(quoted):]
+	}
+	return labels, nil
+}
+
 // Value represents a declared constant.
 type Value struct {
 	original string // The name of the constant.
 	repr     string // The representing name.
+	trimmed  string // The name of the constant without the trimmed prefix.
 	// The value is stored as a bit pattern alone. The boolean tells us
 	// whether to interpret it as an int64 or a uint64; the only place
 	// this matters is when sorting.
 	// Much of the time the str field is all we need; it is printed
 	// by Value.String.
-	value  uint64 // Will be converted to int64 when needed.
-	signed bool   // Whether the constant is a signed type.
-	str    string // The string representation given by the "go/constant" package.
+	value    uint64 // Will be converted to int64 when needed.
+	signed   bool   // Whether the constant is a signed type.
+	str      string // The string representation given by the "go/constant" package.
+	isString bool   // Whether the constant is a string, quoted in str.
+
+	aliases     []string  // Additional names accepted by the lookup.
+	comment     string    // The line comment, used as error message.
+	isDefault   bool      // Returned by the lookup for unknown names.
+	deprecated  bool      // Whether the doc comment has a "Deprecated:" paragraph.
+	deprecation string    // The text of the "Deprecated:" paragraph.
+	category    string    // The first line of the doc comment of the const block.
+	pos         token.Pos // The position of the declaration, for -declorder.
+
+	meta   map[string]string // Metadata given by "//morestringer:meta key=value".
+	labels map[string]string // Names by language given by "//morestringer:label en=\"Name\"".
 }
 
 func (v *Value) String() string {
@@ -202,6 +438,37 @@ func getCName(expr ast.Expr) string {
 	return name
 }
 
+// directives returns the arguments of every "//morestringer:<name>" directive in the comment group.
+// The arguments follow the name after a space or "=".
+func directives(cg *ast.CommentGroup, name string) []string {
+	if cg == nil {
+		return nil
+	}
+	var args []string
+	for _, c := range cg.List {
+		arg, ok := strings.CutPrefix(c.Text, "//morestringer:"+name)
+		if !ok || (arg != "" && arg[0] != ' ' && arg[0] != '\t' && arg[0] != '=') {
+			continue
+		}
+		arg = strings.TrimPrefix(arg, "=")
+		args = append(args, strings.TrimSpace(arg))
+	}
+	return args
+}
+
+// deprecation reports whether the doc comment marks the constant as
+// deprecated, following the convention of a paragraph starting with
+// "Deprecated:", and returns the text of that paragraph.
+func deprecation(doc *ast.CommentGroup) (bool, string) {
+	for para := range strings.SplitSeq(doc.Text(), "\n\n") {
+		msg, ok := strings.CutPrefix(strings.TrimSpace(para), "Deprecated:")
+		if ok {
+			return true, strings.Join(strings.Fields(msg), " ")
+		}
+	}
+	return false, ""
+}
+
 func valueExpr(vspec *ast.ValueSpec, ni int) ast.Expr {
 	if len(vspec.Values) == 0 {
 		return nil
@@ -215,74 +482,210 @@ func valueExpr(vspec *ast.ValueSpec, ni int) ast.Expr {
 	return nil
 }
 
-func (pkg *Package) createValue(name string, cval constant.Value, signed bool, expr ast.Expr, comment *ast.CommentGroup) Value {
+// trim removes the prefix and suffix given by -trimprefix and -trimsuffix
+// from the name of a constant. Both are comma-separated lists of which
+// the first matching one is removed.
+func (n naming) trim(name string) string {
+	for prefix := range strings.SplitSeq(n.trimPrefix, ",") {
+		if strings.HasPrefix(name, prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	for suffix := range strings.SplitSeq(n.trimSuffix, ",") {
+		if strings.HasSuffix(name, suffix) {
+			name = name[:len(name)-len(suffix)]
+			break
+		}
+	}
+	return name
+}
+
+// parseTypes parses the argument of -type, a comma-separated list of type
+// names each optionally followed by overrides of the naming, as in
+// "Status:trimprefix=Status,Level:trimprefix=Lvl:addsuffix=_level". Lists
+// of prefixes or suffixes of a single type are separated by "|", as commas
+// separate the types. It returns the type names and the naming of each type
+// with overrides.
+func parseTypes(arg string, base naming) ([]string, map[string]naming, error) {
+	var types []string
+	typeNaming := make(map[string]naming)
+	for typ := range strings.SplitSeq(arg, ",") {
+		typ, opts, _ := strings.Cut(typ, ":")
+		types = append(types, typ)
+		if opts == "" {
+			continue
+		}
+		n := base
+		for opt := range strings.SplitSeq(opts, ":") {
+			key, value, ok := strings.Cut(opt, "=")
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid option %q of type %s, want key=value", opt, typ)
+			}
+			value = strings.ReplaceAll(value, "|", ",")
+			switch key {
+			case "trimprefix":
+				n.trimPrefix = value
+			case "trimsuffix":
+				n.trimSuffix = value
+			case "addprefix":
+				n.addPrefix = value
+			case "addsuffix":
+				n.addSuffix = value
+			default:
+				return nil, nil, fmt.Errorf("unknown option %q of type %s, want one of trimprefix, trimsuffix, addprefix or addsuffix", key, typ)
+			}
+		}
+		typeNaming[typ] = n
+	}
+	return types, typeNaming, nil
+}
+
+// namingOf returns the naming of the type, which -type can override.
+func (pkg *Package) namingOf(typ string) naming {
+	if n, ok := pkg.typeNaming[typ]; ok {
+		return n
+	}
+	return pkg.naming
+}
+
+func (pkg *Package) createValue(typ, name string, cval constant.Value, signed bool, expr ast.Expr, doc, comment *ast.CommentGroup) Value {
+	n := pkg.namingOf(typ)
 	v := Value{
 		original: name,
+		trimmed:  n.trim(name),
 		signed:   signed,
 		str:      cval.String(),
 	}
-	if i64, ok := constant.Int64Val(cval); ok {
+	if cval.Kind() == constant.String {
+		// The value of a string is its text. It has no bit pattern and
+		// keeps the order of declaration.
+		v.isString = true
+		v.str = strconv.Quote(constant.StringVal(cval))
+	} else if i64, ok := constant.Int64Val(cval); ok {
 		v.value = uint64(i64)
 	} else if u64, ok := constant.Uint64Val(cval); ok {
 		v.value = u64
 	} else {
-		log.Fatalf("internal error: value of %s is not an integer: %s", name, cval.String())
+		failf("internal error: value of %s is not an integer: %s", name, cval.String())
 	}
 
-	if pkg.lineComment && comment != nil && len(comment.List) == 1 {
-		v.repr = strings.TrimSpace(comment.Text())
-	} else if cName := getCName(expr); pkg.cNames && cName != "" {
-		v.repr = strings.TrimPrefix(cName, pkg.trimPrefix)
+	// A line comment holding only directives gives no text.
+	v.comment = strings.TrimSpace(comment.Text())
+	if n.lineComment && v.comment != "" && len(comment.List) == 1 {
+		v.repr = v.comment
+		// Alternates are given as "active|enabled|on". Comments with
+		// empty segments, like the operator "||", are kept as they are.
+		alts := strings.Split(v.comment, "|")
+		for i := range alts {
+			alts[i] = strings.TrimSpace(alts[i])
+		}
+		if len(alts) > 1 && !slices.Contains(alts, "") {
+			v.repr = alts[0]
+			v.aliases = append(v.aliases, alts[1:]...)
+		}
+	} else if cName := getCName(expr); n.cNames && cName != "" {
+		v.repr = n.trim(cName)
+	} else if lit, ok := expr.(*ast.BasicLit); n.runes && ok && lit.Kind == token.CHAR {
+		// Lookups also accept the character itself, as "+" for '+'.
+		v.repr = strconv.QuoteRune(rune(v.value))
+		v.aliases = append(v.aliases, string(rune(v.value)))
+	} else if n.nameTemplate != nil {
+		var b strings.Builder
+		err := n.nameTemplate.Execute(&b, nameData{name, v.trimmed, typ, v.str, v.comment})
+		if err != nil {
+			failf("%s: %s", name, err)
+		}
+		v.repr = b.String()
+	} else if v.isString {
+		v.repr = constant.StringVal(cval)
 	} else {
-		v.repr = strings.TrimPrefix(v.original, pkg.trimPrefix)
+		v.repr = n.trim(v.original)
+	}
+	// A name given by a directive overrides all of the above.
+	switch names := append(directives(doc, "name"), directives(comment, "name")...); {
+	case len(names) > 1:
+		failf("%s: more than one name given by //morestringer:name", name)
+	case len(names) == 1 && names[0] == "":
+		failf("%s: empty name given by //morestringer:name", name)
+	case len(names) == 1:
+		v.repr = names[0]
+	}
+	v.repr = n.addPrefix + v.repr + n.addSuffix
+
+	v.deprecated, v.deprecation = deprecation(doc)
+	v.isDefault = len(directives(doc, "default")) > 0 || len(directives(comment, "default")) > 0
+	for _, arg := range append(directives(doc, "alias"), directives(comment, "alias")...) {
+		for alias := range strings.SplitSeq(arg, ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				v.aliases = append(v.aliases, alias)
+			}
+		}
+	}
+	for _, arg := range append(directives(doc, "meta"), directives(comment, "meta")...) {
+		key, value, ok := strings.Cut(arg, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" {
+			failf("%s: invalid metadata %q, want key=value", name, arg)
+		}
+		if v.meta == nil {
+			v.meta = make(map[string]string)
+		}
+		v.meta[key] = strings.TrimSpace(value)
+	}
+	for _, arg := range append(directives(doc, "label"), directives(comment, "label")...) {
+		labels, err := parseLabels(arg)
+		if err != nil {
+			failf("%s: %s", name, err)
+		}
+		if v.labels == nil {
+			v.labels = make(map[string]string)
+		}
+		for tag, label := range labels {
+			if _, dup := v.labels[tag]; dup {
+				failf("%s: label %s given more than once", name, tag)
+			}
+			v.labels[tag] = label
+		}
 	}
 	return v
 }
 
 // genDecl processes one declaration clause, it stores found types in `typeValues` if type exists.
 func (pkg *Package) genDecl(decl *ast.GenDecl, typeValues map[string][]Value) {
-	// The name of the type of the constants we are declaring.
-	// Can change if this is a multi-element declaration.
-	typ := ""
+	// Constants grouped in a block are categorized by its doc comment.
+	category := ""
+	if decl.Lparen.IsValid() {
+		category, _, _ = strings.Cut(decl.Doc.Text(), "\n")
+		category = strings.TrimSuffix(category, ".")
+	}
 	// Loop over the elements of the declaration. Each element is a ValueSpec:
 	// a list of names possibly followed by a type, possibly followed by values.
-	// If the type and value are both missing, we carry down the type (and value,
-	// but the "go/types" package takes care of that).
+	// The type is resolved by "go/types" rather than read from the syntax, so
+	// that constants typed by an alias, by a conversion, by the value of
+	// another constant as in "Acetaminophen = Paracetamol", or carrying down
+	// the type of a previous line are all matched. Untyped constants have
+	// no type.
 	for _, spec := range decl.Specs {
 		vspec := spec.(*ast.ValueSpec) // Guaranteed to succeed as this is CONST.
-		if vspec.Type == nil && len(vspec.Values) > 0 {
-			// "X = 1". With no type but a value. If the constant is untyped,
-			// skip this vspec and reset the remembered type.
-			typ = ""
-
-			// If this is a simple type conversion, remember the type.
-			// We don't mind if this is actually a call; a qualified call won't
-			// be matched (that will be SelectorExpr, not Ident), and only unusual
-			// situations will result in a function call that appears to be
-			// a type conversion.
-			ce, ok := vspec.Values[0].(*ast.CallExpr)
-			if !ok {
-				continue
-			}
-			id, ok := ce.Fun.(*ast.Ident)
-			if !ok {
-				continue
-			}
-			typ = id.Name
-		}
-		if vspec.Type != nil {
-			// "X T". We have a type. Remember it.
-			ident, ok := vspec.Type.(*ast.Ident)
-			if !ok {
-				continue
-			}
-			typ = ident.Name
+		typ := pkg.constType(vspec.Names[0])
+		if typ == "" {
+			continue
 		}
 		// check if this type is requested
 		values, ok := typeValues[typ]
 		if !ok {
 			continue
 		}
+		// The doc comment of an unparenthesized declaration belongs to the
+		// declaration itself.
+		doc := vspec.Doc
+		if doc == nil && !decl.Lparen.IsValid() {
+			doc = decl.Doc
+		}
+		// Constants marked by "//morestringer:skip" are no values of the type.
+		if len(directives(doc, "skip")) > 0 || len(directives(vspec.Comment, "skip")) > 0 {
+			continue
+		}
 		// We now have a list of names (from one line of source code) all being
 		// declared with the desired type.
 		// Grab their names and actual values and store them in f.values.
@@ -290,57 +693,111 @@ func (pkg *Package) genDecl(decl *ast.GenDecl, typeValues map[string][]Value) {
 			if name.Name == "_" {
 				continue
 			}
+			if isSentinel(name.Name, typ) {
+				if obj, ok := pkg.defs[name].(*types.Const); ok {
+					if pkg.sentinels == nil {
+						pkg.sentinels = make(map[string][]sentinel)
+					}
+					pkg.sentinels[typ] = append(pkg.sentinels[typ], sentinel{name.Name, obj.Val()})
+				}
+				continue
+			}
 			// This dance lets the type checker find the values for us. It's a
 			// bit tricky: look up the object declared by the name, find its
 			// types.Const, and extract its value.
 			obj, ok := pkg.defs[name]
 			if !ok {
-				log.Fatalf("no value for constant %s", name)
+				failf("no value for constant %s", name)
 			}
 			info := obj.Type().Underlying().(*types.Basic).Info()
-			if info&types.IsInteger == 0 {
-				log.Fatalf("can't handle non-integer constant type %s", typ)
+			if info&(types.IsInteger|types.IsString) == 0 {
+				failf("can't handle constant type %s, neither integer nor string", typ)
+			}
+			if pkg.namingOf(typ).runes && obj.Type().Underlying().(*types.Basic).Kind() != types.Int32 {
+				failf("-rune requires type %s to be of rune", typ)
 			}
 			value := obj.(*types.Const).Val() // Guaranteed to succeed as this is CONST.
-			if value.Kind() != constant.Int {
-				log.Fatalf("can't happen: constant is not an integer %s", name)
+			if value.Kind() != constant.Int && value.Kind() != constant.String {
+				failf("can't happen: constant is neither integer nor string %s", name)
 			}
-			values = append(values, pkg.createValue(name.Name, value, info&types.IsUnsigned == 0, valueExpr(vspec, ni), vspec.Comment))
+			v := pkg.createValue(typ, name.Name, value, info&types.IsUnsigned == 0, valueExpr(vspec, ni), doc, vspec.Comment)
+			v.category = category
+			v.pos = name.Pos()
+			values = append(values, v)
 		}
 		typeValues[typ] = values
 	}
 }
 
-func main() {
-	log.SetFlags(0)
-	log.SetPrefix("stringer: ")
-
-	typeNames := flag.String("type", "", "comma-separated list of type names; must be set")
-	output := flag.String("output", "", "output file name; default srcdir/<type>_string.go")
-	trimprefix := flag.String("trimprefix", "", "trim the `prefix` from the generated constant names")
-	linecomment := flag.Bool("linecomment", false, "use line comment text as printed text when present")
-	buildTags := flag.String("tags", "", "comma-separated list of build tags to apply")
-	cNames := flag.Bool("cnames", false, "constant is defined as C.*, use the C-name")
-	genLookup := flag.String("lookup", "", "generate a lookup `function`, \"{}\" is replaced with type")
-	genJson := flag.Bool("json", false, "generate JSONUnmarshal and JSONMarshal methods")
-
-	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, usage)
-		flag.PrintDefaults()
+// isSentinel reports whether the name of a constant of the type follows
+// a convention for counting or bounding the values instead of being one,
+// like _StatusSentinel or statusCount for the type Status.
+func isSentinel(name, typ string) bool {
+	if name == "_"+typ+"Sentinel" {
+		return true
 	}
-	flag.Parse()
-	if len(*typeNames) == 0 {
-		flag.Usage()
-		os.Exit(2)
+	r, size := utf8.DecodeRuneInString(typ)
+	return name == string(unicode.ToLower(r))+typ[size:]+"Count"
+}
+
+// constType returns the name of the type of the constant declared by name
+// if it is a named type of the package, possibly through an alias, or ""
+// otherwise.
+func (pkg *Package) constType(name *ast.Ident) string {
+	obj := pkg.defs[name]
+	if obj == nil {
+		return ""
 	}
-	types := strings.Split(*typeNames, ",")
-	var tags []string
-	if len(*buildTags) > 0 {
-		tags = strings.Split(*buildTags, ",")
+	named, ok := types.Unalias(obj.Type()).(*types.Named)
+	if !ok || named.Obj().Pkg() != pkg.types {
+		return ""
 	}
+	return named.Obj().Name()
+}
+
+// config holds the arguments of generate, as set by the flags. The options
+// of the generated code are the fields of Generator.
+type config struct {
+	args    []string // A directory or the files of a single package, "." when empty.
+	tags    []string // Build tags, which apply to a directory only.
+	types   []string // The names of the types.
+	output  string   // The output file name, srcdir/<type>_string.go when empty.
+	fromPkg string   // The import path of the package declaring the types, for -frompkg.
+	i18n    string   // The directory of the catalogs, for -i18n.
+
+	generator Generator
+
+	naming     naming            // The naming of the constants.
+	typeNaming map[string]naming // Overrides of naming by the type name.
+}
+
+// failure is an error found while analyzing the constants or generating
+// the code, raised by failf and returned by generate.
+type failure struct {
+	err error
+}
+
+// failf stops the generation, which makes generate return the error.
+func failf(format string, args ...any) {
+	panic(failure{fmt.Errorf(format, args...)})
+}
+
+// generate loads the package of cfg.args and writes the generated code of
+// the types. Loading and generating stop with the error of ctx when it is
+// cancelled; no file is written after that.
+func generate(ctx context.Context, cfg config) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(failure)
+			if !ok {
+				panic(r)
+			}
+			err = e.err
+		}
+	}()
 
 	// We accept either one directory or a list of files. Which do we have?
-	args := flag.Args()
+	args := cfg.args
 	if len(args) == 0 {
 		// Default: process whole package in current directory.
 		args = []string{"."}
@@ -349,14 +806,23 @@ func main() {
 	// Parse the package once.
 	var dir string
 	// TODO(suzmue): accept other patterns for packages (directories, list of files, import paths, etc).
-	if len(args) == 1 && isDirectory(args[0]) {
+	isDir := false
+	if len(args) == 1 {
+		if isDir, err = isDirectory(args[0]); err != nil {
+			return err
+		}
+	}
+	if isDir {
 		dir = args[0]
 	} else {
-		if len(tags) != 0 {
-			log.Fatal("-tags option applies only to directories, not when files are specified")
+		if len(cfg.tags) != 0 {
+			return errors.New("-tags option applies only to directories, not when files are specified")
 		}
 		dir = filepath.Dir(args[0])
 	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// For each type, generate code in the first package where the type is declared.
 	// The order of packages is as follows:
@@ -369,7 +835,21 @@ func main() {
 	// from which they were generated.
 	//
 	// Types will be excluded when generated, to avoid repetitions.
-	pkgs := loadPackages(args, tags, *trimprefix, *linecomment, *cNames)
+	// The patterns are resolved in dir, so that a directory in another
+	// module is loaded from that module.
+	patterns := []string{"."}
+	if !isDir {
+		patterns = make([]string, len(args))
+		for i, arg := range args {
+			if patterns[i], err = filepath.Abs(arg); err != nil {
+				return err
+			}
+		}
+	}
+	pkgs, err := loadPackages(ctx, dir, patterns, cfg.tags, cfg.naming, cfg.typeNaming)
+	if err != nil {
+		return err
+	}
 	slices.SortFunc(pkgs, func(left, right *Package) int {
 		iTest := strings.HasSuffix(left.name, "_test")
 		jTest := strings.HasSuffix(right.name, "_test")
@@ -380,16 +860,319 @@ func main() {
 		return cmp.Compare(len(left.files), len(right.files))
 	})
 
-	g := Generator{
-		lookup: *genLookup,
-		json:   *genJson,
+	g := cfg.generator
+	g.from = nil
+	// The package declaring the types, without its tests.
+	if cfg.fromPkg != "" {
+		from, err := loadPackages(ctx, dir, []string{cfg.fromPkg}, cfg.tags, cfg.naming, cfg.typeNaming)
+		if err != nil {
+			return err
+		}
+		for _, pkg := range from {
+			if !pkg.hasTestFiles && !strings.HasSuffix(pkg.name, "_test") {
+				g.from = pkg
+				break
+			}
+		}
+		if g.from == nil {
+			return fmt.Errorf("no package %s", cfg.fromPkg)
+		}
 	}
+	if cfg.i18n != "" {
+		g.catalogs, err = readCatalogs(cfg.i18n)
+		if err != nil {
+			return fmt.Errorf("reading catalogs: %s", err)
+		}
+	}
+
+	types := cfg.types
 	for _, pkg := range pkgs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		g.buf.Reset()
-		types = g.genPackage(pkg, types, dir, *output)
+		remaining, err := g.genPackage(ctx, pkg, types, dir, cfg.output)
+		if err != nil {
+			return err
+		}
+		types = remaining
 	}
 
 	if len(types) > 0 {
-		log.Fatalf("no values defined for types: %s", strings.Join(types, ","))
+		return fmt.Errorf("no values defined for types: %s", strings.Join(types, ","))
+	}
+	return nil
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("stringer: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of type names, each optionally followed by :trimprefix=, :trimsuffix=, :addprefix= or :addsuffix=; must be set")
+	output := flag.String("output", "", "output file name; default srcdir/<type>_string.go")
+	acronyms := flag.String("acronyms", "", "comma-separated `list` of acronyms, such as ID,HTTP,URL, kept intact when converting the case of names")
+	trimprefix := flag.String("trimprefix", "", "trim the `prefix` from the generated constant names; a comma-separated list trims the first matching prefix")
+	addprefix := flag.String("addprefix", "", "add the `prefix` to the generated names, as a namespace")
+	addsuffix := flag.String("addsuffix", "", "add the `suffix` to the generated names")
+	nameTemplate := flag.String("nametemplate", "", "derive the names from the constants by the text/template `template`, as in '{{.Name | trimPrefix \"Status\" | snake}}'")
+	trimsuffix := flag.String("trimsuffix", "", "trim the `suffix` from the generated constant names; a comma-separated list trims the first matching suffix")
+	linecomment := flag.Bool("linecomment", false, "use line comment text as printed text when present")
+	buildTags := flag.String("tags", "", "comma-separated list of build tags to apply")
+	cNames := flag.Bool("cnames", false, "constant is defined as C.*, use the C-name")
+	runes := flag.Bool("rune", false, "for types of rune, name constants declared by character literals by the quoted rune, as '+', and accept the character in lookups")
+	genLookup := flag.String("lookup", "", "generate a lookup `function` shaped [Recv.]Name[(string|[]byte)][ bool|error], \"{}\" in Name is replaced with type")
+	lookupBytes := flag.Bool("lookupbytes", false, "generate LookupTBytes, a lookup taking []byte")
+	lookupStrategy := flag.String("lookup-strategy", "auto", "`strategy` of the lookup, one of hash, perfect, binary, map or auto")
+	hashMax := flag.Int("hashmax", defaultHashMax, "maximum number of names using a hash-switch lookup with -lookup-strategy=auto, 0 disables it")
+	binaryMax := flag.Int("binarymax", defaultBinaryMax, "maximum number of names using a binary search lookup with -lookup-strategy=auto, 0 disables it")
+	fold := flag.Bool("fold", false, "match names in lookups case-insensitively after Unicode normalization; requires golang.org/x/text")
+	ignoreSep := flag.Bool("ignoresep", false, "ignore case, '-', '_' and spaces when matching names in lookups")
+	genParse := newModeFlag("parse", "generate a ParseT function returning an error for unknown names, with -parse=must also MustParseT", "error", "must")
+	parseNumbers := newModeFlag("parsenumbers", "accept numbers in ParseT, of defined constants or with -parsenumbers=any all numbers", "defined", "any")
+	genIter := newModeFlag("iter", "generate TAll returning an iter.Seq over all values, with -iter=all2 also TAll2 yielding names; with -flags also a Flags method", "all", "all2")
+	genNext := newModeFlag("next", "generate Next and Prev methods stepping to the adjacent value, clamping at the ends or with -next=wrap wrapping around", "clamp", "wrap")
+	genOrdinal := flag.Bool("ordinal", false, "generate an Ordinal method and TFromOrdinal mapping values to their index among all values")
+	genDeprecated := newModeFlag("deprecated", "generate an IsDeprecated method for constants documented as deprecated, with -deprecated=message also DeprecatedMessage", "is", "message")
+	genCategory := flag.Bool("category", false, "generate a Category method returning the doc comment of the const block of a value")
+	genMeta := flag.Bool("meta", false, "generate a Meta method returning metadata given by //morestringer:meta key=value")
+	genRandom := flag.Bool("random", false, "generate RandomT and a Generate method implementing testing/quick.Generator")
+	genSet := flag.Bool("set", false, "generate TSet, a bitset of values")
+	genEnumMap := flag.Bool("enummap", false, "generate TMap, a generic map keyed by values backed by an array; implies -ordinal")
+	genValidate := flag.Bool("validate", false, "generate a Validate method returning an InvalidTError for undefined values")
+	genClamp := flag.Bool("clamp", false, "generate a Clamp method returning the nearest defined value")
+	genPredicates := flag.Bool("predicates", false, "generate an IsName method for each constant")
+	genEnums := flag.Bool("enums", false, "register the types in EnumTypes, declared in the generated file enums.go; implies -parse and -values")
+	genRuntime := flag.Bool("runtime", false, "register the types with github.com/friedelschoen/morestringer/enum; implies -parse")
+	genWireName := newModeFlag("wirename", "generate a WireName method returning the identifier in snake_case, or with -wirename=kebab or screaming in kebab-case or SCREAMING_SNAKE_CASE", "snake", "kebab", "screaming")
+	genDebugString := flag.Bool("debugstring", false, "generate a DebugString method returning names qualified by the type, as in T.Name")
+	genFingerprint := flag.Bool("fingerprint", false, "generate TFingerprint returning a hash of the names and values")
+	genSlice := flag.Bool("slice", false, "generate ParseTSlice for lists of values and TNames joining the names by a separator; implies -parse")
+	genCount := newModeFlag("count", "generate the constant _TCount holding the number of values, with -count=exported also NumT", "unexported", "exported")
+	genMinMax := flag.Bool("minmax", false, "generate TMin and TMax returning the smallest and largest value")
+	genIsValid := flag.Bool("isvalid", false, "generate an IsValid method reporting whether a value is defined")
+	genValues := flag.Bool("values", false, "generate TValues and TNames listing all values and their names")
+	genOptions := flag.Bool("options", false, "generate TOptions pairing all values with their names, for HTML select elements")
+	i18n := flag.String("i18n", "", "directory of catalogs `<locale>.json` translating the names, embedded for a StringIn method")
+	genMessage := flag.Bool("message", false, "generate MessageKey and TSetMessages for golang.org/x/text/message catalogs")
+	fromPkg := flag.String("frompkg", "", "import `path` of the package declaring the types; they are declared again in the output, with the generated methods")
+	genLabel := flag.Bool("label", false, "generate a Label method returning names by language.Tag given by //morestringer:label en=\"Name\"")
+	declOrder := flag.Bool("declorder", false, "list values in the order of their declaration instead of ascending order in TValues, TNames, TOptions, TUsage and TAll")
+	genUsage := flag.Bool("usage", false, "generate TUsage listing the names as \"one of: A, B, C\" for usage strings")
+	genTemplateFuncs := flag.Bool("templatefuncs", false, "generate TTemplateFuncs returning a template.FuncMap of the helpers; implies -parse and -values")
+	flags := flag.Bool("flags", false, "generate a String method joining the names of the set bits, for values combining flags")
+	flagSep := flag.String("flagsep", "|", "`separator` of the names of flags with -flags")
+	flagUnknown := flag.String("flagunknown", "hex", "`format` of bits without a name with -flags, one of hex, decimal or type")
+	lock := flag.Bool("lock", false, "record the names and values of the types in enum.lock next to the output")
+	compatCheck := flag.Bool("compat-check", false, "fail if a name recorded in enum.lock was removed or changed its value")
+	genRegistry := flag.Bool("registry", false, "generate LookupEnum, looking up values of all types by the name of their type")
+	genJson := newModeFlag("json", "generate JSONUnmarshal and JSONMarshal methods, marshaling the name or number", "name", "number")
+	genJsonV2 := newModeFlag("jsonv2", "generate MarshalJSONTo and UnmarshalJSONFrom methods for encoding/json/v2", "name", "number")
+	genGraphql := flag.Bool("graphql", false, "generate MarshalGQL and UnmarshalGQL methods for gqlgen using SCREAMING_SNAKE_CASE names")
+	genSlog := flag.Bool("slog", false, "generate a LogValue method implementing slog.LogValuer")
+	genZap := flag.Bool("zap", false, "generate a MarshalLogObject method implementing zapcore.ObjectMarshaler")
+	genFormatter := flag.Bool("formatter", false, "generate a Format method implementing fmt.Formatter")
+	genGoString := flag.Bool("gostring", false, "generate a GoString method returning the qualified constant name")
+	genFlagValue := flag.Bool("flagvalue", false, "generate Set and Get methods implementing flag.Getter and urfave/cli's Generic")
+	genCobra := flag.Bool("cobra", false, "generate ValidArgs and completion helpers for github.com/spf13/cobra")
+	genKong := flag.Bool("kong", false, "generate a Decode method implementing kong.MapperValue; implies -flagvalue")
+	genMapstructure := flag.Bool("mapstructure", false, "generate a DecodeHook function for github.com/go-viper/mapstructure/v2")
+	genError := newModeFlag("error", "generate an Error method using the line comment as message, with -error=is also an Is method", "methods", "is")
+	genValidator := flag.Bool("validator", false, "generate a validation for go-playground/validator")
+	genPrometheus := flag.Bool("prometheus", false, "generate label helpers for Prometheus metrics")
+	genProto := flag.String("proto", "", "generate conversions from and to the protobuf enum `pkg.EnumName`")
+	unknown := flag.String("unknown", "error", "`handling` of unknown names in UnmarshalText and UnmarshalJSON, one of error, zero or number")
+	checkNumbers := flag.Bool("checknumbers", false, "reject numbers in UnmarshalJSON and UnmarshalJSONFrom which are not a defined constant")
+	genText := flag.Bool("text", false, "generate MarshalText and UnmarshalText methods")
+	genSql := flag.Bool("sql", false, "generate Scan and Value methods for database/sql")
+	genSqlNull := flag.Bool("sqlnull", false, "generate a nullable Null<type> wrapper for database/sql; implies -sql")
+	genYaml := flag.Bool("yaml", false, "generate MarshalYAML and UnmarshalYAML methods for gopkg.in/yaml.v3")
+	genToml := flag.Bool("toml", false, "generate TOML support; implies -text")
+	genXml := flag.Bool("xml", false, "generate MarshalXML and UnmarshalXML methods, also for attributes")
+	genBson := newModeFlag("bson", "generate MarshalBSONValue and UnmarshalBSONValue methods", "name", "number")
+	genCbor := flag.Bool("cbor", false, "generate MarshalCBOR and UnmarshalCBOR methods for github.com/fxamacker/cbor/v2")
+	genBinary := flag.Bool("binary", false, "generate MarshalBinary and UnmarshalBinary methods using the smallest fitting width")
+	genGob := newModeFlag("gob", "generate GobEncode and GobDecode methods, with -gob=register also registered in init", "methods", "register")
+	genMsgpack := flag.Bool("msgpack", false, "generate EncodeMsgpack and DecodeMsgpack methods for github.com/vmihailenco/msgpack/v5")
+
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if len(*typeNames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if !slices.Contains([]string{"hash", "perfect", "binary", "map", "auto"}, *lookupStrategy) {
+		log.Fatalf("unknown -lookup-strategy=%s, want one of hash, perfect, binary, map or auto", *lookupStrategy)
+	}
+	if !slices.Contains([]string{"error", "zero", "number"}, *unknown) {
+		log.Fatalf("unknown -unknown=%s, want one of error, zero or number", *unknown)
+	}
+	if !slices.Contains([]string{"hex", "decimal", "type"}, *flagUnknown) {
+		log.Fatalf("unknown -flagunknown=%s, want one of hex, decimal or type", *flagUnknown)
+	}
+	var acronymList []string
+	if *acronyms != "" {
+		acronymList = strings.Split(*acronyms, ",")
+	}
+	base := naming{
+		trimPrefix:  *trimprefix,
+		trimSuffix:  *trimsuffix,
+		addPrefix:   *addprefix,
+		addSuffix:   *addsuffix,
+		lineComment: *linecomment,
+		cNames:      *cNames,
+		runes:       *runes,
+	}
+	if *nameTemplate != "" {
+		var err error
+		base.nameTemplate, err = parseNameTemplate(*nameTemplate, acronymList)
+		if err != nil {
+			log.Fatalf("invalid -nametemplate: %s", err)
+		}
+	}
+	types, typeNaming, err := parseTypes(*typeNames, base)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var tags []string
+	if len(*buildTags) > 0 {
+		tags = strings.Split(*buildTags, ",")
+	}
+	cfg := config{
+		args:       flag.Args(),
+		tags:       tags,
+		types:      types,
+		output:     *output,
+		fromPkg:    *fromPkg,
+		i18n:       *i18n,
+		naming:     base,
+		typeNaming: typeNaming,
+	}
+
+	if *genToml {
+		// Both BurntSushi/toml and pelletier/go-toml encode values
+		// implementing encoding.TextMarshaler as strings.
+		*genText = true
+	}
+
+	if *genSqlNull {
+		*genSql = true
+	}
+	if *genKong {
+		*genFlagValue = true
+	}
+	if *genSlice && (*genValues || *genEnums || *genTemplateFuncs) {
+		// TNames of -slice joins the names, the others return them.
+		log.Fatal("-slice can not be combined with -values, -enums or -templatefuncs, which declare TNames() []string")
+	}
+	if *genEnums || *genTemplateFuncs {
+		*genValues = true
+	}
+	if *genEnumMap {
+		*genOrdinal = true
+	}
+	if (*parseNumbers != "" || *genSlice || *genEnums || *genRuntime || *genTemplateFuncs) && *genParse == "" {
+		*genParse = "error"
+	}
+
+	var lookup string
+	var lookupWrapper lookupSig
+	if *genLookup != "" {
+		sig, err := parseLookupSig(*genLookup)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if sig.plain() {
+			lookup = sig.name
+		} else {
+			lookupWrapper = sig
+		}
+	}
+
+	cfg.generator = Generator{
+		lookup:         lookup,
+		lookupWrapper:  lookupWrapper,
+		lookupBytes:    *lookupBytes,
+		lookupStrategy: *lookupStrategy,
+		hashMax:        *hashMax,
+		binaryMax:      *binaryMax,
+		fold:           *fold,
+		ignoreSep:      *ignoreSep,
+		parse:          *genParse,
+		parseNumbers:   *parseNumbers,
+		slice:          *genSlice,
+		values:         *genValues,
+		options:        *genOptions,
+		declOrder:      *declOrder,
+		message:        *genMessage,
+		label:          *genLabel,
+		acronyms:       acronymList,
+		usage:          *genUsage,
+		templateFuncs:  *genTemplateFuncs,
+		flags:          *flags,
+		flagSep:        *flagSep,
+		flagUnknown:    *flagUnknown,
+		lock:           *lock,
+		compatCheck:    *compatCheck,
+		iter:           *genIter,
+		next:           *genNext,
+		ordinal:        *genOrdinal,
+		deprecated:     *genDeprecated,
+		category:       *genCategory,
+		meta:           *genMeta,
+		random:         *genRandom,
+		set:            *genSet,
+		enumMap:        *genEnumMap,
+		validate:       *genValidate,
+		clamp:          *genClamp,
+		predicates:     *genPredicates,
+		enums:          *genEnums,
+		runtime:        *genRuntime,
+		wireName:       *genWireName,
+		debugString:    *genDebugString,
+		fingerprint:    *genFingerprint,
+		count:          *genCount,
+		minMax:         *genMinMax,
+		isValid:        *genIsValid,
+		registry:       *genRegistry,
+		json:           *genJson,
+		text:           *genText,
+		sql:            *genSql,
+		sqlnull:        *genSqlNull,
+		yaml:           *genYaml,
+		xml:            *genXml,
+		bson:           *genBson,
+		cbor:           *genCbor,
+		msgpack:        *genMsgpack,
+		binary:         *genBinary,
+		gob:            *genGob,
+		jsonv2:         *genJsonV2,
+		graphql:        *genGraphql,
+		slog:           *genSlog,
+		zap:            *genZap,
+		formatter:      *genFormatter,
+		gostring:       *genGoString,
+		flagValue:      *genFlagValue,
+		cobra:          *genCobra,
+		kong:           *genKong,
+		mapstructure:   *genMapstructure,
+		error:          *genError,
+		validator:      *genValidator,
+		prometheus:     *genPrometheus,
+		proto:          *genProto,
+
+		checkNumbers: *checkNumbers,
+		unknown:      *unknown,
+	}
+
+	// Stop loading and generating when interrupted, so a cancelled
+	// go generate does not leave behind half of the output files.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err = generate(ctx, cfg)
+	stop()
+	if err != nil {
+		log.Fatal(err)
 	}
 }
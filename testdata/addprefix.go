@@ -0,0 +1,28 @@
+// Check that -addprefix and -addsuffix namespace the names.
+
+package main
+
+import "fmt"
+
+type Addprefix int
+
+const (
+	PhaseRunning Addprefix = iota
+	PhaseSucceeded
+	PhaseFailed
+)
+
+func main() {
+	ck(PhaseRunning, "k8s.io/Running.phase")
+	ck(PhaseFailed, "k8s.io/Failed.phase")
+	var a Addprefix
+	if err := a.UnmarshalText([]byte("k8s.io/Succeeded.phase")); err != nil || a != PhaseSucceeded {
+		panic(fmt.Sprintf("addprefix.go: UnmarshalText = %v, %v", a, err))
+	}
+}
+
+func ck(a Addprefix, str string) {
+	if fmt.Sprint(a) != str {
+		panic("addprefix.go: " + str)
+	}
+}
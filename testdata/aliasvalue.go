@@ -0,0 +1,34 @@
+// Check that the lookup accepts constants sharing the value of
+// another constant, while String returns the first name.
+
+package main
+
+import "fmt"
+
+type Aliasvalue int
+
+const (
+	Placebo Aliasvalue = iota
+	Aspirin
+	Ibuprofen
+	Paracetamol
+	Acetaminophen = Paracetamol
+	ASA, Motrin   = Aspirin, Ibuprofen
+)
+
+func main() {
+	ck("Paracetamol", Paracetamol)
+	ck("Acetaminophen", Paracetamol)
+	ck("ASA", Aspirin)
+	ck("Motrin", Ibuprofen)
+	if s := Acetaminophen.String(); s != "Paracetamol" {
+		panic(fmt.Sprintf("aliasvalue.go: Acetaminophen.String() = %q", s))
+	}
+}
+
+func ck(name string, want Aliasvalue) {
+	got, err := ParseAliasvalue(name)
+	if err != nil || got != want {
+		panic(fmt.Sprintf("aliasvalue.go: ParseAliasvalue(%q) = %v, %v", name, got, err))
+	}
+}
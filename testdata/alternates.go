@@ -0,0 +1,42 @@
+// Check that -linecomment accepts alternates separated by "|",
+// printing the first one.
+
+package main
+
+import "fmt"
+
+type Alternates int
+
+const (
+	Active   Alternates = iota // active|enabled | on
+	Inactive                   // inactive|disabled|off
+	Or                         // ||
+)
+
+func main() {
+	ck(Active, "active")
+	ck(Inactive, "inactive")
+	ck(Or, "||")
+	ckText("enabled", Active)
+	ckText("on", Active)
+	ckText("off", Inactive)
+	ckText("||", Or)
+	var a Alternates
+	if err := a.UnmarshalText([]byte("active|enabled")); err == nil {
+		panic("alternates.go: active|enabled accepted")
+	}
+}
+
+func ck(a Alternates, str string) {
+	if a.String() != str {
+		panic(fmt.Sprintf("alternates.go: %q != %q", a.String(), str))
+	}
+	ckText(str, a)
+}
+
+func ckText(str string, want Alternates) {
+	var a Alternates
+	if err := a.UnmarshalText([]byte(str)); err != nil || a != want {
+		panic(fmt.Sprintf("alternates.go: unmarshal text %q = %v, %v", str, a, err))
+	}
+}
@@ -0,0 +1,30 @@
+// Check that unsigned values above MaxInt64 are not printed as negative.
+
+package main
+
+import "fmt"
+
+type Big uint64
+
+const (
+	Small Big = 1
+	Huge  Big = 1<<63 + 1
+	Max   Big = 1<<64 - 1
+)
+
+func main() {
+	ck(Small, "Small")
+	ck(Huge, "Huge")
+	ck(Max, "Max")
+	ck(1<<63, "Big(9223372036854775808)")
+	ck(1<<64-2, "Big(18446744073709551614)")
+	if err := Big(1 << 63).Validate(); err == nil || err.Error() != "invalid Big: 9223372036854775808" {
+		panic(fmt.Sprintf("big.go: Validate = %v", err))
+	}
+}
+
+func ck(big Big, str string) {
+	if fmt.Sprint(big) != str {
+		panic("big.go: " + str)
+	}
+}
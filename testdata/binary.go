@@ -0,0 +1,47 @@
+// Check that MarshalBinary generated with -binary uses the smallest
+// width for the values and that UnmarshalBinary validates the range.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type Binary int32
+
+const (
+	Low     Binary = -200
+	Zero    Binary = 0
+	High    Binary = 300
+	Highest Binary = 1000
+)
+
+func main() {
+	ck(Low, []byte{0xff, 0x38})
+	ck(Zero, []byte{0x00, 0x00})
+	ck(High, []byte{0x01, 0x2c})
+	ck(Highest, []byte{0x03, 0xe8})
+
+	var b Binary
+	if err := b.UnmarshalBinary([]byte{0x03, 0xe9}); err == nil {
+		panic("binary.go: 1001 accepted")
+	}
+	if err := b.UnmarshalBinary([]byte{0xff, 0x37}); err == nil {
+		panic("binary.go: -201 accepted")
+	}
+	if err := b.UnmarshalBinary([]byte{0x00}); err == nil {
+		panic("binary.go: short input accepted")
+	}
+}
+
+func ck(b Binary, want []byte) {
+	data, err := b.MarshalBinary()
+	if err != nil || !bytes.Equal(data, want) {
+		panic(fmt.Sprintf("binary.go: %s encoded as %x", b, data))
+	}
+	var u Binary
+	if err := u.UnmarshalBinary(data); err != nil || u != b {
+		panic("binary.go: unmarshal " + b.String())
+	}
+}
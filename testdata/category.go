@@ -0,0 +1,40 @@
+// Check the Category method generated by -category.
+
+package main
+
+import "fmt"
+
+type Category int
+
+// Client errors.
+//
+// These are caused by the request.
+const (
+	BadRequest   Category = 400
+	Unauthorized Category = 401
+	NotFound     Category = 404
+)
+
+// Server errors.
+const (
+	Internal    Category = 500
+	Unavailable Category = 503
+)
+
+const (
+	Unknown Category = 0
+)
+
+func main() {
+	ck(BadRequest, "Client errors")
+	ck(NotFound, "Client errors")
+	ck(Unavailable, "Server errors")
+	ck(Unknown, "")
+	ck(42, "")
+}
+
+func ck(i Category, want string) {
+	if got := i.Category(); got != want {
+		panic(fmt.Sprintf("category.go: %v.Category() = %q", i, got))
+	}
+}
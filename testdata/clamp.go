@@ -0,0 +1,33 @@
+// Check the Clamp method generated by -clamp, for sparse signed values.
+
+package main
+
+import "fmt"
+
+type Clamp int8
+
+const (
+	V1 Clamp = iota - 1
+	V2
+	V3
+	V10 Clamp = 10
+	V20 Clamp = 20
+)
+
+func main() {
+	ck(-128, V1)
+	ck(V1, V1)
+	ck(V3, V3)
+	ck(2, V3)
+	ck(5, V3) // Equally near to V3 and V10.
+	ck(6, V10)
+	ck(15, V10)
+	ck(16, V20)
+	ck(127, V20)
+}
+
+func ck(i, want Clamp) {
+	if got := i.Clamp(); got != want {
+		panic(fmt.Sprintf("clamp.go: Clamp(%d) = %d", int8(i), int8(got)))
+	}
+}
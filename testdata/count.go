@@ -0,0 +1,27 @@
+// Check the constants generated by -count=exported.
+
+package main
+
+import "fmt"
+
+type Count int
+
+const (
+	North Count = iota
+	East
+	South
+	West
+	Up Count = 10
+)
+
+// Sized by the generated constant, so adding a value needs no manual update.
+var labels [_CountCount]string
+
+func main() {
+	if len(labels) != 5 {
+		panic(fmt.Sprintf("count.go: _CountCount = %d", len(labels)))
+	}
+	if NumCount != 5 {
+		panic(fmt.Sprintf("count.go: NumCount = %d", NumCount))
+	}
+}
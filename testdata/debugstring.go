@@ -0,0 +1,24 @@
+// Check the DebugString method generated by -debugstring.
+
+package main
+
+import "fmt"
+
+type Debugstring int
+
+const (
+	Active Debugstring = iota
+	Deleted
+)
+
+func main() {
+	ck(Active, "Debugstring.Active")
+	ck(Deleted, "Debugstring.Deleted")
+	ck(5, "Debugstring(5)")
+}
+
+func ck(i Debugstring, want string) {
+	if got := i.DebugString(); got != want {
+		panic(fmt.Sprintf("debugstring.go: DebugString() = %q", got))
+	}
+}
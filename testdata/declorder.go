@@ -0,0 +1,39 @@
+// Check that -declorder lists the values in the order of their declaration.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Declorder int
+
+const (
+	Medium Declorder = 5
+	Low    Declorder = 1
+	High   Declorder = 10
+	Normal           = Medium
+	Off    Declorder = 0
+)
+
+func main() {
+	values := []Declorder{Medium, Low, High, Off}
+	if got := DeclorderValues(); !slices.Equal(got, values) {
+		panic(fmt.Sprintf("declorder.go: DeclorderValues = %v", got))
+	}
+	names := []string{"Medium", "Low", "High", "Off"}
+	if got := DeclorderNames(); !slices.Equal(got, names) {
+		panic(fmt.Sprintf("declorder.go: DeclorderNames = %q", got))
+	}
+	if got := DeclorderUsage(); got != "one of: Medium, Low, High, Off" {
+		panic(fmt.Sprintf("declorder.go: DeclorderUsage = %q", got))
+	}
+	var all []Declorder
+	for v := range DeclorderAll() {
+		all = append(all, v)
+	}
+	if !slices.Equal(all, values) {
+		panic(fmt.Sprintf("declorder.go: DeclorderAll = %v", all))
+	}
+}
@@ -0,0 +1,28 @@
+// Check that the lookup returns the constant marked by
+// //morestringer:default for unknown names.
+
+package main
+
+import "fmt"
+
+type Default int
+
+const (
+	Red Default = iota + 1
+	Green
+	//morestringer:default
+	Unknown
+)
+
+func main() {
+	ck("Green", Green, true)
+	ck("Unknown", Unknown, true)
+	ck("Blue", Unknown, false)
+}
+
+func ck(name string, want Default, wantOk bool) {
+	got, ok := DefaultByName(name)
+	if got != want || ok != wantOk {
+		panic(fmt.Sprintf("default.go: DefaultByName(%q) = %v, %v", name, got, ok))
+	}
+}
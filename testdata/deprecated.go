@@ -0,0 +1,39 @@
+// Check IsDeprecated and DeprecatedMessage generated by -deprecated=message.
+
+package main
+
+import "fmt"
+
+type Deprecated int
+
+const (
+	Plain Deprecated = iota
+	// Secure uses TLS.
+	Secure
+	// Legacy is the old protocol.
+	//
+	// Deprecated: Use Secure instead,
+	// as Legacy is unencrypted.
+	Legacy
+	Current
+
+	// Deprecated: Renamed to Current.
+	Latest = Current
+)
+
+func main() {
+	ck(Plain, false, "")
+	ck(Secure, false, "")
+	ck(Legacy, true, "Use Secure instead, as Legacy is unencrypted.")
+	// Only an old name of Current is deprecated.
+	ck(Latest, false, "")
+}
+
+func ck(i Deprecated, deprecated bool, msg string) {
+	if got := i.IsDeprecated(); got != deprecated {
+		panic(fmt.Sprintf("deprecated.go: %v.IsDeprecated() = %t", i, got))
+	}
+	if got := i.DeprecatedMessage(); got != msg {
+		panic(fmt.Sprintf("deprecated.go: %v.DeprecatedMessage() = %q", i, got))
+	}
+}
@@ -0,0 +1,42 @@
+// Check the map type generated by -enummap, for sparse values.
+
+package main
+
+import "fmt"
+
+type Enummap int
+
+const (
+	Alpha Enummap = iota
+	Beta
+	Gamma Enummap = 100
+	Delta Enummap = -5
+)
+
+func main() {
+	var m EnummapMap[string]
+	m.Set(Gamma, "gamma")
+	m.Set(Delta, "delta")
+	m.Set(Alpha, "alpha")
+	m.Set(42, "undefined")
+	if v, ok := m.Get(Gamma); v != "gamma" || !ok {
+		panic(fmt.Sprintf("enummap.go: Get(Gamma) = %q, %t", v, ok))
+	}
+	if v, ok := m.Get(Beta); v != "" || ok {
+		panic(fmt.Sprintf("enummap.go: Get(Beta) = %q, %t", v, ok))
+	}
+	if _, ok := m.Get(42); ok {
+		panic("enummap.go: Get(42) succeeded")
+	}
+	m.Delete(Alpha)
+	if m.Len() != 2 {
+		panic(fmt.Sprintf("enummap.go: Len() = %d", m.Len()))
+	}
+	var got []string
+	for i, v := range m.Range {
+		got = append(got, i.String()+"="+v)
+	}
+	if fmt.Sprint(got) != "[Delta=delta Gamma=gamma]" {
+		panic(fmt.Sprintf("enummap.go: Range = %v", got))
+	}
+}
@@ -0,0 +1,45 @@
+// Check that -error=is generates error messages from line comments,
+// and that error codes can be matched with errors.Is.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Errcode int
+
+const (
+	NotFound Errcode = iota + 1 // resource not found
+	Denied                      // permission denied
+	Busy
+)
+
+func main() {
+	ck(NotFound, "resource not found")
+	ck(Denied, "permission denied")
+	ck(Busy, "Busy")
+	ck(Errcode(7), "Errcode(7)")
+	err := fmt.Errorf("open: %w", Denied)
+	if !errors.Is(err, Denied) {
+		panic("errcode.go: errors.Is(err, Denied) is false")
+	}
+	if errors.Is(err, NotFound) {
+		panic("errcode.go: errors.Is(err, NotFound) is true")
+	}
+	var code Errcode
+	if !errors.As(err, &code) || code != Denied {
+		panic("errcode.go: errors.As failed")
+	}
+	d := Denied
+	if !Denied.Is(&d) {
+		panic("errcode.go: Denied.Is(&Denied) is false")
+	}
+}
+
+func ck(err error, str string) {
+	if err.Error() != str {
+		panic(fmt.Sprintf("errcode.go: %q != %q", err.Error(), str))
+	}
+}
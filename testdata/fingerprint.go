@@ -0,0 +1,26 @@
+// Check TFingerprint generated by -fingerprint against the documented hash.
+
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+type Fingerprint int8
+
+const (
+	Minus Fingerprint = -1
+	Zero  Fingerprint = 0
+	Seven Fingerprint = 7
+)
+
+func main() {
+	h := fnv.New64a()
+	for _, i := range []Fingerprint{Minus, Zero, Seven} {
+		fmt.Fprintf(h, "%s:%d\n", i, int8(i))
+	}
+	if got := FingerprintFingerprint(); got != h.Sum64() {
+		panic(fmt.Sprintf("fingerprint.go: FingerprintFingerprint() = %#x, want %#x", got, h.Sum64()))
+	}
+}
@@ -0,0 +1,36 @@
+// Check the Flags method generated by -flags with -iter, yielding the flags
+// in the order of their declaration.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Flagiter int16
+
+const (
+	Write Flagiter = 2
+	Read  Flagiter = 1
+	Exec  Flagiter = 4
+)
+
+func main() {
+	ck(0, nil)
+	ck(Exec|Read, []Flagiter{Read, Exec})
+	ck(Exec|Write|Read, []Flagiter{Write, Read, Exec})
+	ck(Write|64, []Flagiter{Write, 64})
+	ck(-1<<15|Read, []Flagiter{Read, -1 << 15})
+	for f := range (Read | Write | Exec).Flags() {
+		if f == Write {
+			break
+		}
+	}
+}
+
+func ck(flags Flagiter, want []Flagiter) {
+	if got := slices.Collect(flags.Flags()); !slices.Equal(got, want) {
+		panic(fmt.Sprintf("flagiter.go: %v.Flags() = %v, want %v", flags, got, want))
+	}
+}
@@ -0,0 +1,46 @@
+// Check -json marshaling combinations of flags as arrays with -flags.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Flagjson uint16
+
+const (
+	Read Flagjson = 1 << iota
+	Write
+	Exec
+)
+
+func main() {
+	marshal(0, `[]`)
+	marshal(Read|Exec, `["Read","Exec"]`)
+	marshal(Write|32, `["Write",32]`)
+
+	unmarshal(`["Exec","Read"]`, Read|Exec)
+	unmarshal(`["Write",32]`, Write|32)
+	unmarshal(`"Read|Write"`, Read|Write)
+	unmarshal(`[]`, 0)
+	unmarshal(`4`, Exec)
+	var f Flagjson
+	if err := json.Unmarshal([]byte(`["Read","Wirte"]`), &f); err == nil {
+		panic("flagjson.go: unmarshaling Wirte did not fail")
+	}
+}
+
+func marshal(f Flagjson, want string) {
+	b, err := json.Marshal(f)
+	if err != nil || string(b) != want {
+		panic(fmt.Sprintf("flagjson.go: Marshal(%v) = %s, %v; want %s", f, b, err, want))
+	}
+}
+
+func unmarshal(text string, want Flagjson) {
+	var f Flagjson
+	if err := json.Unmarshal([]byte(text), &f); err != nil || f != want {
+		panic(fmt.Sprintf("flagjson.go: Unmarshal(%s) = %v, %v; want %v", text, f, err, want))
+	}
+}
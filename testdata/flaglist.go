@@ -0,0 +1,41 @@
+// Check the listings of -values, -options, -enums and -templatefuncs
+// combined with -flags, naming values which are not a single bit.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Flaglist uint8
+
+const (
+	None     Flaglist = 0
+	Read     Flaglist = 1 << iota
+	Write
+	ReadWrite = Read | Write
+	Exec      Flaglist = 1 << 4
+)
+
+func main() {
+	values := []Flaglist{None, Read, Write, ReadWrite, Exec}
+	names := []string{"None", "Read", "Write", "ReadWrite", "Exec"}
+	if got := FlaglistValues(); !slices.Equal(got, values) {
+		panic(fmt.Sprintf("flaglist.go: FlaglistValues = %v", got))
+	}
+	if got := FlaglistNames(); !slices.Equal(got, names) {
+		panic(fmt.Sprintf("flaglist.go: FlaglistNames = %q", got))
+	}
+	for i, option := range FlaglistOptions() {
+		if option.Value != values[i] || option.Label != names[i] {
+			panic(fmt.Sprintf("flaglist.go: FlaglistOptions()[%d] = %v", i, option))
+		}
+	}
+	if got := EnumTypes["Flaglist"].Names; !slices.Equal(got, names) {
+		panic(fmt.Sprintf("flaglist.go: EnumTypes names = %q", got))
+	}
+	if FlaglistTemplateFuncs()["FlaglistNames"] == nil {
+		panic("flaglist.go: no FlaglistNames template func")
+	}
+}
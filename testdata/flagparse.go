@@ -0,0 +1,49 @@
+// Check parsing combinations of flags with -flags.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Flagparse uint8
+
+const (
+	None  Flagparse = 0
+	Read  Flagparse = 1 << iota
+	Write
+	Exec
+	ReadWrite = Read | Write
+)
+
+func main() {
+	ck("Read", Read)
+	ck("Read|Write", Read|Write)
+	ck("Write | Exec", Write|Exec)
+	ck("ReadWrite|Exec", Read|Write|Exec)
+	ck("None", None)
+	ck(Read.String(), Read)
+	ck((Read | Exec).String(), Read|Exec)
+
+	_, err := ParseFlagparse("Read|Wirte|Ecex")
+	if !errors.Is(err, ErrInvalidFlagparse) || !strings.Contains(err.Error(), `"Wirte"`) {
+		panic(fmt.Sprintf("flagparse.go: ParseFlagparse error %v does not name Wirte", err))
+	}
+	var f Flagparse
+	err = f.UnmarshalText([]byte("Read|Ecex"))
+	if err == nil || !strings.Contains(err.Error(), `"Ecex"`) {
+		panic(fmt.Sprintf("flagparse.go: UnmarshalText error %v does not name Ecex", err))
+	}
+	if err := f.UnmarshalText([]byte("Exec|Read")); err != nil || f != Read|Exec {
+		panic(fmt.Sprintf("flagparse.go: UnmarshalText = %v, %v", f, err))
+	}
+}
+
+func ck(name string, want Flagparse) {
+	got, err := ParseFlagparse(name)
+	if err != nil || got != want {
+		panic(fmt.Sprintf("flagparse.go: ParseFlagparse(%q) = %v, %v; want %v", name, got, err, want))
+	}
+}
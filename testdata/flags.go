@@ -0,0 +1,39 @@
+// Check String generated by -flags, joining the names of set bits.
+
+package main
+
+import "fmt"
+
+type Flags uint8
+
+const (
+	None  Flags = 0
+	Read  Flags = 1 << iota
+	Write
+	Exec
+	ReadWrite = Read | Write
+	Sticky    Flags = 1 << 6
+)
+
+func main() {
+	ck(None, "None")
+	ck(Read, "Read")
+	ck(Write|Read, "Read|Write")
+	ck(ReadWrite|Exec, "Read|Write|Exec")
+	ck(Read|Sticky, "Read|Sticky")
+	ck(Read|16, "Read|0x10")
+	ck(32|128, "0xa0")
+
+	if got := (Read | Sticky | 16 | 128).Invalid(); got != 16|128 {
+		panic(fmt.Sprintf("flags.go: Invalid = %d", got))
+	}
+	if !Read.IsSubsetOf(ReadWrite) || (Read | Exec).IsSubsetOf(ReadWrite) {
+		panic("flags.go: IsSubsetOf")
+	}
+}
+
+func ck(flags Flags, str string) {
+	if fmt.Sprint(flags) != str {
+		panic("flags.go: " + str)
+	}
+}
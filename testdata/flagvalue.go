@@ -0,0 +1,43 @@
+// Check that the type works with the flag package using -flagvalue.
+
+package main
+
+import (
+	"flag"
+	"io"
+)
+
+type Flagvalue int
+
+const (
+	Fast Flagvalue = iota
+	Balanced
+	Thorough
+)
+
+func main() {
+	ck([]string{}, Balanced)
+	ck([]string{"-mode", "Fast"}, Fast)
+	ck([]string{"-mode=Thorough"}, Thorough)
+
+	fs := flag.NewFlagSet("flagvalue", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	mode := Balanced
+	fs.Var(&mode, "mode", "")
+	err := fs.Parse([]string{"-mode=Slow"})
+	if err == nil || err.Error() != `invalid value "Slow" for flag -mode: invalid Flagvalue "Slow", must be one of: Fast, Balanced, Thorough` {
+		panic("flagvalue.go: Slow accepted")
+	}
+}
+
+func ck(args []string, want Flagvalue) {
+	fs := flag.NewFlagSet("flagvalue", flag.ContinueOnError)
+	mode := Balanced
+	fs.Var(&mode, "mode", "")
+	if err := fs.Parse(args); err != nil || mode != want {
+		panic("flagvalue.go: " + want.String())
+	}
+	if fs.Lookup("mode").Value.(flag.Getter).Get() != want {
+		panic("flagvalue.go: get " + want.String())
+	}
+}
@@ -0,0 +1,34 @@
+// Check the verbs of the Format method generated with -formatter.
+
+package main
+
+import "fmt"
+
+type Formatter int
+
+const (
+	Placebo Formatter = iota
+	Aspirin
+	Ibuprofen
+	Paracetamol Formatter = 255
+)
+
+func main() {
+	ck("%v", Aspirin, "Aspirin")
+	ck("%s", Ibuprofen, "Ibuprofen")
+	ck("%q", Placebo, `"Placebo"`)
+	ck("%d", Ibuprofen, "2")
+	ck("%x", Paracetamol, "ff")
+	ck("%#X", Paracetamol, "0XFF")
+	ck("%04d", Aspirin, "0001")
+	ck("%-8s|", Aspirin, "Aspirin |")
+	ck("%.3s", Paracetamol, "Par")
+	ck("%v", Formatter(3), "Formatter(3)")
+	ck("%d", Formatter(-3), "-3")
+}
+
+func ck(format string, f Formatter, str string) {
+	if got := fmt.Sprintf(format, f); got != str {
+		panic(fmt.Sprintf("formatter.go: %s gives %q, want %q", format, got, str))
+	}
+}
@@ -0,0 +1,52 @@
+// Check that values survive a gob round-trip by name when stored
+// in an interface-typed field, using -gob=register.
+
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+type Gob int
+
+const (
+	North Gob = iota
+	East
+	South
+	West
+)
+
+type Message struct {
+	Payload any
+}
+
+func main() {
+	ck(North)
+	ck(East)
+	ck(South)
+	ck(West)
+
+	data, err := East.GobEncode()
+	if err != nil || string(data) != "East" {
+		panic("gob.go: East not encoded by name")
+	}
+	var g Gob
+	if err := g.GobDecode([]byte("Up")); err == nil {
+		panic("gob.go: Up accepted")
+	}
+}
+
+func ck(g Gob) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(Message{g}); err != nil {
+		panic("gob.go: encode " + g.String() + ": " + err.Error())
+	}
+	var m Message
+	if err := gob.NewDecoder(&buf).Decode(&m); err != nil {
+		panic("gob.go: decode " + g.String() + ": " + err.Error())
+	}
+	if m.Payload != g {
+		panic("gob.go: " + g.String())
+	}
+}
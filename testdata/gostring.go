@@ -0,0 +1,31 @@
+// Check that %#v prints qualified constant names with -gostring,
+// also when the type implements fmt.Formatter.
+
+package main
+
+import "fmt"
+
+type Gostring int
+
+const (
+	Read Gostring = iota + 1
+	Write
+	Execute
+)
+
+type Permission struct {
+	Mode Gostring
+}
+
+func main() {
+	ck(Read, "main.Read")
+	ck(Execute, "main.Execute")
+	ck(Gostring(7), "main.Gostring(7)")
+	ck(Permission{Write}, "main.Permission{Mode:main.Write}")
+}
+
+func ck(v any, str string) {
+	if got := fmt.Sprintf("%#v", v); got != str {
+		panic(fmt.Sprintf("gostring.go: %q != %q", got, str))
+	}
+}
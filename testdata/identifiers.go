@@ -0,0 +1,31 @@
+// Check that the lookup accepts both the printed names and the
+// identifiers of constants, with -trimprefix and -linecomment.
+
+package main
+
+import "fmt"
+
+type Identifiers int
+
+const (
+	StatusPending Identifiers = iota
+	StatusActive              // active
+	StatusClosed              // StatusPending
+)
+
+func main() {
+	ck("Pending", StatusPending, true)
+	ck("active", StatusActive, true)
+	ck("StatusActive", StatusActive, true)
+	// The printed name of StatusClosed wins over the identifier of StatusPending.
+	ck("StatusPending", StatusClosed, true)
+	ck("StatusClosed", StatusClosed, true)
+	ck("Active", 0, false)
+}
+
+func ck(name string, want Identifiers, wantOk bool) {
+	got, ok := IdentifiersByName(name)
+	if got != want || ok != wantOk {
+		panic(fmt.Sprintf("identifiers.go: IdentifiersByName(%q) = %v, %v", name, got, ok))
+	}
+}
@@ -0,0 +1,31 @@
+// Check that -ignoresep matches names regardless of case and separators.
+
+package main
+
+import "fmt"
+
+type Ignoresep int
+
+const (
+	NotFound Ignoresep = iota
+	Timeout
+	//morestringer:alias Conn-Reset
+	ConnectionReset
+)
+
+func main() {
+	ck("NotFound", NotFound, true)
+	ck("not_found", NotFound, true)
+	ck("not-found", NotFound, true)
+	ck("NOT FOUND", NotFound, true)
+	ck("time_out", Timeout, true)
+	ck("conn_reset", ConnectionReset, true)
+	ck("NotFond", 0, false)
+}
+
+func ck(name string, want Ignoresep, wantOk bool) {
+	got, ok := IgnoresepByName(name)
+	if got != want || ok != wantOk {
+		panic(fmt.Sprintf("ignoresep.go: IgnoresepByName(%q) = %v, %v", name, got, ok))
+	}
+}
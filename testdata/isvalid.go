@@ -0,0 +1,31 @@
+// Check the IsValid method generated by -isvalid, for several runs of values.
+
+package main
+
+import "fmt"
+
+type Isvalid uint16
+
+const (
+	Get Isvalid = iota
+	Put
+	Delete
+	Patch   Isvalid = 10
+	Options Isvalid = 1000
+)
+
+func main() {
+	ck(Get, true)
+	ck(Delete, true)
+	ck(Patch, true)
+	ck(Options, true)
+	ck(3, false)
+	ck(11, false)
+	ck(65535, false)
+}
+
+func ck(i Isvalid, want bool) {
+	if got := i.IsValid(); got != want {
+		panic(fmt.Sprintf("isvalid.go: %d.IsValid() = %t", uint16(i), got))
+	}
+}
@@ -0,0 +1,37 @@
+// Check the iterators generated by -iter=all2.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Iter int
+
+const (
+	Spring Iter = iota
+	Summer
+	Autumn
+	Winter
+	Never Iter = 100
+)
+
+func main() {
+	if got := slices.Collect(IterAll()); !slices.Equal(got, []Iter{Spring, Summer, Autumn, Winter, Never}) {
+		panic(fmt.Sprintf("iter.go: IterAll = %v", got))
+	}
+	var names []string
+	for i, name := range IterAll2() {
+		if name != i.String() {
+			panic(fmt.Sprintf("iter.go: IterAll2 yielded %q for %v", name, i))
+		}
+		names = append(names, name)
+		if i == Autumn {
+			break
+		}
+	}
+	if !slices.Equal(names, []string{"Spring", "Summer", "Autumn"}) {
+		panic(fmt.Sprintf("iter.go: IterAll2 = %q", names))
+	}
+}
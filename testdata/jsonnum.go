@@ -0,0 +1,53 @@
+// Check that -json=number marshals the numeric value while
+// unmarshaling still accepts both names and numbers, and that
+// -checknumbers rejects undefined numbers.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Jsonnum int
+
+const (
+	Unknown Jsonnum = iota
+	Started
+	Stopped
+	Failed Jsonnum = 10
+)
+
+func main() {
+	ck(Unknown, "0")
+	ck(Started, "1")
+	ck(Stopped, "2")
+	ckUnmarshal(`"Stopped"`, Stopped)
+	ckUnmarshal(`1`, Started)
+	ckUnmarshal(`10`, Failed)
+
+	var j Jsonnum
+	if err := json.Unmarshal([]byte(`"Paused"`), &j); err == nil {
+		panic("jsonnum.go: Paused accepted")
+	}
+	for _, str := range []string{`3`, `-1`, `1.5`} {
+		if err := json.Unmarshal([]byte(str), &j); err == nil {
+			panic("jsonnum.go: " + str + " accepted")
+		}
+	}
+}
+
+func ck(j Jsonnum, str string) {
+	b, err := json.Marshal(j)
+	if err != nil || string(b) != str {
+		panic(fmt.Sprintf("jsonnum.go: %s marshaled as %s", j, b))
+	}
+	ckUnmarshal(str, j)
+}
+
+func ckUnmarshal(str string, want Jsonnum) {
+	var j Jsonnum
+	if err := json.Unmarshal([]byte(str), &j); err != nil || j != want {
+		panic("jsonnum.go: unmarshal " + str)
+	}
+}
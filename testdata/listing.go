@@ -0,0 +1,32 @@
+// Check TValues and TNames generated by -values, for several runs of values.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Listing int
+
+const (
+	Zero Listing = iota
+	One
+	Two
+	Ten    Listing = 10
+	Twenty Listing = iota + 16
+	TwentyOne
+	Twelve = Ten + 2
+	Dozen  = Twelve
+)
+
+func main() {
+	values := []Listing{Zero, One, Two, Ten, Twelve, Twenty, TwentyOne}
+	if got := ListingValues(); !slices.Equal(got, values) {
+		panic(fmt.Sprintf("listing.go: ListingValues = %v", got))
+	}
+	names := []string{"Zero", "One", "Two", "Ten", "Twelve", "Twenty", "TwentyOne"}
+	if got := ListingNames(); !slices.Equal(got, names) {
+		panic(fmt.Sprintf("listing.go: ListingNames = %q", got))
+	}
+}
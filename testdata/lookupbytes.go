@@ -0,0 +1,34 @@
+// Check that LookupLookupbytesBytes finds names without allocating.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+type Lookupbytes int
+
+const (
+	Short Lookupbytes = iota
+	AVeryLongNameWhichDoesNotFitIntoSmallBuffers
+)
+
+func main() {
+	ck("Short", Short, true)
+	ck("AVeryLongNameWhichDoesNotFitIntoSmallBuffers", AVeryLongNameWhichDoesNotFitIntoSmallBuffers, true)
+	ck("AVeryLongNameWhichDoesNotFitIntoSmallBuffersEither", 0, false)
+	ck("Long", 0, false)
+
+	name := []byte("AVeryLongNameWhichDoesNotFitIntoSmallBuffers")
+	if n := testing.AllocsPerRun(100, func() { LookupLookupbytesBytes(name) }); n != 0 {
+		panic(fmt.Sprintf("lookupbytes.go: LookupLookupbytesBytes allocates %v times", n))
+	}
+}
+
+func ck(name string, want Lookupbytes, wantOk bool) {
+	got, ok := LookupLookupbytesBytes([]byte(name))
+	if got != want || ok != wantOk {
+		panic(fmt.Sprintf("lookupbytes.go: LookupLookupbytesBytes(%q) = %v, %v", name, got, ok))
+	}
+}
@@ -0,0 +1,28 @@
+// Check a lookup shaped by -lookup as a method taking []byte and
+// returning an error.
+
+package main
+
+import "fmt"
+
+type Lookupsig int
+
+const (
+	North Lookupsig = iota
+	East
+	South
+	West
+)
+
+type Registry struct{}
+
+func main() {
+	var r *Registry
+	got, err := r.LookupsigByName([]byte("South"))
+	if err != nil || got != South {
+		panic(fmt.Sprintf("lookupsig.go: LookupsigByName(South) = %v, %v", got, err))
+	}
+	if _, err := r.LookupsigByName([]byte("Up")); err == nil || err.Error() != `invalid Lookupsig: "Up"` {
+		panic(fmt.Sprintf("lookupsig.go: LookupsigByName(Up) gave error %v", err))
+	}
+}
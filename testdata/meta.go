@@ -0,0 +1,34 @@
+// Check the Meta method generated by -meta.
+
+package main
+
+import "fmt"
+
+type Meta int
+
+const (
+	//morestringer:meta severity=info
+	//morestringer:meta http=200
+	Ok       Meta = iota
+	NotFound      //morestringer:meta http = 404
+	Plain
+	//morestringer:meta severity=error
+	//morestringer:meta summary=Something went wrong
+	Failed
+)
+
+func main() {
+	ck(Ok, "severity", "info")
+	ck(Ok, "http", "200")
+	ck(NotFound, "http", "404")
+	ck(NotFound, "severity", "")
+	ck(Plain, "http", "")
+	ck(Failed, "summary", "Something went wrong")
+	ck(42, "http", "")
+}
+
+func ck(i Meta, key, want string) {
+	if got := i.Meta(key); got != want {
+		panic(fmt.Sprintf("meta.go: %v.Meta(%q) = %q", i, key, got))
+	}
+}
@@ -0,0 +1,24 @@
+// Check TMin and TMax generated by -minmax, with negative values.
+
+package main
+
+import "fmt"
+
+type Minmax int8
+
+const (
+	Cold Minmax = -40
+	Warm Minmax = 20
+	Zero Minmax = 0
+	Hot  Minmax = 90
+	Mild Minmax = 21
+)
+
+func main() {
+	if MinmaxMin() != Cold {
+		panic(fmt.Sprintf("minmax.go: MinmaxMin = %v", MinmaxMin()))
+	}
+	if MinmaxMax() != Hot {
+		panic(fmt.Sprintf("minmax.go: MinmaxMax = %v", MinmaxMax()))
+	}
+}
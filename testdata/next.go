@@ -0,0 +1,37 @@
+// Check Next and Prev generated by -next=wrap, skipping holes between runs.
+
+package main
+
+import "fmt"
+
+type Next uint8
+
+const (
+	Red Next = iota
+	Green
+	Blue
+	Cyan  Next = 10
+	Black Next = iota + 16
+	White
+)
+
+func main() {
+	ck(Red, Green, White)
+	ck(Blue, Cyan, Green)
+	ck(Cyan, Black, Blue)
+	ck(Black, White, Cyan)
+	ck(White, Red, Black)
+	// Values in the holes step to the closest defined value.
+	ck(5, Cyan, Blue)
+	ck(15, Black, Cyan)
+	ck(255, Red, White)
+}
+
+func ck(i, next, prev Next) {
+	if got := i.Next(); got != next {
+		panic(fmt.Sprintf("next.go: %v.Next() = %v", i, got))
+	}
+	if got := i.Prev(); got != prev {
+		panic(fmt.Sprintf("next.go: %v.Prev() = %v", i, got))
+	}
+}
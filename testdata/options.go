@@ -0,0 +1,35 @@
+// Check TOptions generated by -options, labeled by line comments.
+
+package main
+
+import "fmt"
+
+type Options int
+
+const (
+	Small  Options = iota // S
+	Medium                // M
+	Large                 // L
+	Huge   Options = 10   // XL
+)
+
+func main() {
+	want := []struct {
+		Value Options
+		Label string
+	}{
+		{Small, "S"},
+		{Medium, "M"},
+		{Large, "L"},
+		{Huge, "XL"},
+	}
+	got := OptionsOptions()
+	if len(got) != len(want) {
+		panic(fmt.Sprintf("options.go: OptionsOptions = %v", got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			panic(fmt.Sprintf("options.go: OptionsOptions()[%d] = %v, want %v", i, got[i], want[i]))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Check Ordinal and TFromOrdinal generated by -ordinal, for sparse values.
+
+package main
+
+import "fmt"
+
+type Ordinal int16
+
+const (
+	Minus Ordinal = iota - 2
+	MinusOne
+	Zero
+	Five    Ordinal = 5
+	Hundred Ordinal = iota + 96
+	HundredOne
+)
+
+func main() {
+	for n, i := range []Ordinal{Minus, MinusOne, Zero, Five, Hundred, HundredOne} {
+		if got := i.Ordinal(); got != n {
+			panic(fmt.Sprintf("ordinal.go: %v.Ordinal() = %d, want %d", i, got, n))
+		}
+		if got, ok := OrdinalFromOrdinal(n); got != i || !ok {
+			panic(fmt.Sprintf("ordinal.go: OrdinalFromOrdinal(%d) = %v, %t", n, got, ok))
+		}
+	}
+	if got := Ordinal(3).Ordinal(); got != -1 {
+		panic(fmt.Sprintf("ordinal.go: Ordinal(3).Ordinal() = %d", got))
+	}
+	for _, n := range []int{-1, 6} {
+		if _, ok := OrdinalFromOrdinal(n); ok {
+			panic(fmt.Sprintf("ordinal.go: OrdinalFromOrdinal(%d) succeeded", n))
+		}
+	}
+}
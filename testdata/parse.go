@@ -0,0 +1,53 @@
+// Check that -parse returns errors wrapping ErrInvalidParse, suggesting
+// close names, and that MustParseParse panics for unknown names.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Parse int
+
+const (
+	Alpha Parse = iota
+	Beta
+	Gamma
+)
+
+func main() {
+	ck("Alpha", Alpha)
+	ck("Gamma", Gamma)
+	_, err := ParseParse("Delta")
+	if !errors.Is(err, ErrInvalidParse) {
+		panic(fmt.Sprintf("parse.go: %v does not wrap ErrInvalidParse", err))
+	}
+	if got := err.Error(); got != `invalid Parse: "Delta", did you mean "Beta"?` {
+		panic(fmt.Sprintf("parse.go: unexpected error %q", got))
+	}
+	_, err = ParseParse("Omega")
+	if got := err.Error(); got != `invalid Parse: "Omega"` {
+		panic(fmt.Sprintf("parse.go: unexpected error %q", got))
+	}
+	_, err = ParseParse("gama")
+	if got := err.Error(); got != `invalid Parse: "gama", did you mean "Gamma"?` {
+		panic(fmt.Sprintf("parse.go: unexpected error %q", got))
+	}
+	if MustParseParse("Beta") != Beta {
+		panic("parse.go: MustParseParse(\"Beta\") != Beta")
+	}
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrInvalidParse) {
+			panic(fmt.Sprintf("parse.go: MustParseParse(\"Delta\") did not panic with ErrInvalidParse: %v", err))
+		}
+	}()
+	MustParseParse("Delta")
+}
+
+func ck(name string, want Parse) {
+	got, err := ParseParse(name)
+	if err != nil || got != want {
+		panic(fmt.Sprintf("parse.go: ParseParse(%q) = %v, %v", name, got, err))
+	}
+}
@@ -0,0 +1,34 @@
+// Check that -parsenumbers=any accepts all numbers fitting the type.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Parseany int8
+
+const (
+	Off Parseany = iota
+	On
+)
+
+func main() {
+	ck("On", On)
+	ck("1", On)
+	ck("0x40", 64)
+	ck("-0b101", -5)
+	for _, name := range []string{"128", "Of", "0xg"} {
+		if _, err := ParseParseany(name); !errors.Is(err, ErrInvalidParseany) {
+			panic(fmt.Sprintf("parseany.go: ParseParseany(%q) did not fail: %v", name, err))
+		}
+	}
+}
+
+func ck(name string, want Parseany) {
+	got, err := ParseParseany(name)
+	if err != nil || got != want {
+		panic(fmt.Sprintf("parseany.go: ParseParseany(%q) = %v, %v", name, got, err))
+	}
+}
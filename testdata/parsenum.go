@@ -0,0 +1,36 @@
+// Check that -parsenumbers accepts the numbers of defined constants only.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Parsenum uint8
+
+const (
+	Red   Parsenum = 1
+	Green Parsenum = 2
+	Blue  Parsenum = 7
+)
+
+func main() {
+	ck("Green", Green)
+	ck("2", Green)
+	ck("7", Blue)
+	ck("0x7", Blue)
+	ck("0o7", Blue)
+	for _, name := range []string{"0", "3", "-1", "257", "0x3"} {
+		if _, err := ParseParsenum(name); !errors.Is(err, ErrInvalidParsenum) {
+			panic(fmt.Sprintf("parsenum.go: ParseParsenum(%q) did not fail: %v", name, err))
+		}
+	}
+}
+
+func ck(name string, want Parsenum) {
+	got, err := ParseParsenum(name)
+	if err != nil || got != want {
+		panic(fmt.Sprintf("parsenum.go: ParseParsenum(%q) = %v, %v", name, got, err))
+	}
+}
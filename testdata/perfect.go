@@ -0,0 +1,37 @@
+// Check the lookup generated with -lookup-strategy=perfect.
+
+package main
+
+import "fmt"
+
+type Perfect int
+
+const (
+	Mercury Perfect = iota
+	Venus
+	Earth
+	Mars
+	Jupiter
+	Saturn
+	Uranus
+	Neptune
+	//morestringer:alias Planet9
+	Pluto
+)
+
+func main() {
+	for i := Mercury; i <= Pluto; i++ {
+		ck(i.String(), i, true)
+	}
+	ck("Planet9", Pluto, true)
+	ck("Vulcan", 0, false)
+	ck("", 0, false)
+	ck("Earthling", 0, false)
+}
+
+func ck(name string, want Perfect, wantOk bool) {
+	got, ok := PerfectByName(name)
+	if got != want || ok != wantOk {
+		panic(fmt.Sprintf("perfect.go: PerfectByName(%q) = %v, %v", name, got, ok))
+	}
+}
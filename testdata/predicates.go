@@ -0,0 +1,28 @@
+// Check the predicates generated by -predicates, with -trimprefix.
+
+package main
+
+type Predicates int
+
+const (
+	StatusActive Predicates = iota
+	StatusDeleted
+	Status2FA              // Trimmed to no identifier, so IsStatus2FA.
+	StatusGone             = StatusDeleted
+	statusDraft Predicates = 10
+)
+
+func main() {
+	if !StatusActive.IsActive() || StatusActive.IsDeleted() {
+		panic("predicates.go: StatusActive")
+	}
+	if !StatusDeleted.IsDeleted() || !StatusDeleted.IsGone() {
+		panic("predicates.go: StatusDeleted")
+	}
+	if !Status2FA.IsStatus2FA() || !statusDraft.IsStatusDraft() {
+		panic("predicates.go: untrimmed names")
+	}
+	if Predicates(42).IsActive() {
+		panic("predicates.go: Predicates(42).IsActive()")
+	}
+}
@@ -0,0 +1,39 @@
+// Check RandomT and the quick.Generator generated by -random.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing/quick"
+)
+
+type Random int
+
+const (
+	Rock Random = iota
+	Paper
+	Scissors
+	Lizard Random = 10
+)
+
+func main() {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[Random]bool)
+	for range 1000 {
+		i := RandomRandom(r)
+		if i != Rock && i != Paper && i != Scissors && i != Lizard {
+			panic(fmt.Sprintf("random.go: RandomRandom = %d", int(i)))
+		}
+		seen[i] = true
+	}
+	if len(seen) != 4 {
+		panic(fmt.Sprintf("random.go: RandomRandom returned only %v", seen))
+	}
+	valid := func(i Random) bool {
+		return i.String() != fmt.Sprintf("Random(%d)", int(i))
+	}
+	if err := quick.Check(valid, nil); err != nil {
+		panic(fmt.Sprintf("random.go: quick.Check: %v", err))
+	}
+}
@@ -0,0 +1,35 @@
+// Check the predicates generated for "//morestringer:range" directives.
+
+package main
+
+import "fmt"
+
+// Ranges is an HTTP status code.
+//
+//morestringer:range Informational 100..199
+//morestringer:range Success OK..NoContent
+//morestringer:range ClientError 400..499
+type Ranges int
+
+const (
+	Continue  Ranges = 100
+	OK        Ranges = 200
+	Created   Ranges = 201
+	NoContent Ranges = 204
+	NotFound  Ranges = 404
+	Internal  Ranges = 500
+)
+
+func main() {
+	ck(Continue, true, false, false)
+	ck(OK, false, true, false)
+	ck(NoContent, false, true, false)
+	ck(NotFound, false, false, true)
+	ck(Internal, false, false, false)
+}
+
+func ck(i Ranges, info, success, client bool) {
+	if i.IsInformational() != info || i.IsSuccess() != success || i.IsClientError() != client {
+		panic(fmt.Sprintf("ranges.go: unexpected predicates for %v", i))
+	}
+}
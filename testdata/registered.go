@@ -0,0 +1,35 @@
+// Check the registration of types in EnumTypes generated by -enums.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Registered int
+
+const (
+	Small Registered = iota
+	Medium
+	Large
+)
+
+func main() {
+	e, ok := EnumTypes["Registered"]
+	if !ok || e.Name != "Registered" {
+		panic(fmt.Sprintf("registered.go: EnumTypes = %v", EnumTypes))
+	}
+	if !slices.Equal(e.Values, []any{Small, Medium, Large}) {
+		panic(fmt.Sprintf("registered.go: Values = %v", e.Values))
+	}
+	if !slices.Equal(e.Names, []string{"Small", "Medium", "Large"}) {
+		panic(fmt.Sprintf("registered.go: Names = %v", e.Names))
+	}
+	if v, err := e.Parse("Medium"); v != Medium || err != nil {
+		panic(fmt.Sprintf("registered.go: Parse(Medium) = %v, %v", v, err))
+	}
+	if _, err := e.Parse("Huge"); err == nil {
+		panic("registered.go: Parse(Huge) succeeded")
+	}
+}
@@ -0,0 +1,27 @@
+// Check that LookupEnum resolves values by the name of their type.
+
+package main
+
+import "fmt"
+
+type Registry int
+
+const (
+	Local Registry = iota
+	Remote
+	Mirror
+)
+
+func main() {
+	ck("Registry", "Remote", Remote, true)
+	ck("Registry", "Mirror", Mirror, true)
+	ck("Registry", "Nowhere", nil, false)
+	ck("Unknown", "Remote", nil, false)
+}
+
+func ck(typeName, valueName string, want any, wantOk bool) {
+	got, ok := LookupEnum(typeName, valueName)
+	if got != want || ok != wantOk {
+		panic(fmt.Sprintf("registry.go: LookupEnum(%q, %q) = %v, %v", typeName, valueName, got, ok))
+	}
+}
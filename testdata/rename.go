@@ -0,0 +1,28 @@
+// Check overriding single names with the //morestringer:name directive.
+
+package main
+
+import "fmt"
+
+type Rename int
+
+const (
+	StatusOK Rename = iota
+	//morestringer:name=NotFound
+	Status404
+	StatusGone   //morestringer:name Gone away
+	StatusTeapot // I'm a teapot
+)
+
+func main() {
+	ck(StatusOK, "OK")
+	ck(Status404, "NotFound")
+	ck(StatusGone, "Gone away")
+	ck(StatusTeapot, "I'm a teapot")
+}
+
+func ck(r Rename, str string) {
+	if fmt.Sprint(r) != str {
+		panic("rename.go: " + str)
+	}
+}
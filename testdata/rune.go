@@ -0,0 +1,39 @@
+// Check that -rune prints character literals as quoted runes and looks
+// them up by the character.
+
+package main
+
+import "fmt"
+
+type Rune rune
+
+const (
+	EOF   Rune = -1
+	Tab   Rune = '\t'
+	Plus  Rune = '+'
+	Minus Rune = '-'
+	Alpha Rune = 'α'
+)
+
+func main() {
+	ck(EOF, "EOF")
+	ck(Tab, `'\t'`)
+	ck(Plus, "'+'")
+	ck(Minus, "'-'")
+	ck(Alpha, "'α'")
+	ck(Rune('*'), "Rune(42)")
+	for name, want := range map[string]Rune{"+": Plus, "'+'": Plus, "\t": Tab, "α": Alpha, "EOF": EOF} {
+		if got, err := ParseRune(name); err != nil || got != want {
+			panic(fmt.Sprintf("rune.go: ParseRune(%q) = %v, %v", name, got, err))
+		}
+	}
+	if _, err := ParseRune("*"); err == nil {
+		panic("rune.go: ParseRune(\"*\") succeeded")
+	}
+}
+
+func ck(r Rune, str string) {
+	if fmt.Sprint(r) != str {
+		panic("rune.go: " + str)
+	}
+}
@@ -0,0 +1,45 @@
+// Check the bitsets generated by -set, backed by a single word.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Set uint8
+
+const (
+	Read Set = iota
+	Write
+	Exec
+	Admin Set = 40
+)
+
+func main() {
+	s := NewSetSet(Read, Exec)
+	s.Add(Admin)
+	s.Add(Exec)
+	s.Add(200) // Out of range, ignored.
+	if !s.Contains(Read) || s.Contains(Write) || !s.Contains(Admin) || s.Contains(200) {
+		panic(fmt.Sprintf("set.go: unexpected contents %v", s))
+	}
+	if s.Len() != 3 {
+		panic(fmt.Sprintf("set.go: Len() = %d", s.Len()))
+	}
+	if got := s.String(); got != "{Read, Exec, Admin}" {
+		panic(fmt.Sprintf("set.go: String() = %q", got))
+	}
+	s.Remove(Exec)
+	var got []Set
+	for i := range s.Iterate {
+		got = append(got, i)
+	}
+	if !slices.Equal(got, []Set{Read, Admin}) {
+		panic(fmt.Sprintf("set.go: Iterate = %v", got))
+	}
+	if fmt.Sprint(SetSet(0)) != "{}" {
+		panic("set.go: empty set")
+	}
+
+}
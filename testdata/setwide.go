@@ -0,0 +1,26 @@
+// Check the bitsets generated by -set, backed by an array for values
+// spanning more than 64 bits.
+
+package main
+
+import "fmt"
+
+type Setwide int16
+
+const (
+	Low  Setwide = -100
+	Mid  Setwide = 0
+	High Setwide = 100
+)
+
+func main() {
+	s := NewSetwideSet(High, Low)
+	if !s.Contains(Low) || !s.Contains(High) || s.Contains(Mid) || s.Contains(-1000) || s.Len() != 2 {
+		panic(fmt.Sprintf("setwide.go: unexpected contents %v", s))
+	}
+	s.Add(Mid)
+	s.Remove(High)
+	if got := s.String(); got != "{Low, Mid}" {
+		panic(fmt.Sprintf("setwide.go: String() = %q", got))
+	}
+}
@@ -0,0 +1,43 @@
+// Check that constants marked by //morestringer:skip and sentinels are no values.
+
+package main
+
+import "fmt"
+
+type Skip int
+
+const (
+	Low Skip = iota
+	Medium
+	High
+	skipCount // Counts the values, not one of them.
+)
+
+const (
+	Up Skip = iota + 10
+	//morestringer:skip
+	Unused
+	Down
+	_SkipSentinel
+)
+
+const maxSkip Skip = 100 //morestringer:skip
+
+func main() {
+	ck(Low, "Low")
+	ck(High, "High")
+	ck(skipCount, "Skip(3)")
+	ck(Unused, "Skip(11)")
+	ck(Down, "Down")
+	ck(_SkipSentinel, "Skip(13)")
+	ck(maxSkip, "Skip(100)")
+	if _, ok := _lookup_Skip("skipCount"); ok {
+		panic("skip.go: skipCount is looked up")
+	}
+}
+
+func ck(s Skip, str string) {
+	if fmt.Sprint(s) != str {
+		panic("skip.go: " + str)
+	}
+}
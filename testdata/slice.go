@@ -0,0 +1,30 @@
+// Check the helpers generated by -slice.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type Slice int
+
+const (
+	Debug Slice = iota
+	Info
+	Warn
+)
+
+func main() {
+	got, err := ParseSliceSlice([]string{"Info", "Debug", "Info"})
+	if err != nil || !slices.Equal(got, []Slice{Info, Debug, Info}) {
+		panic(fmt.Sprintf("slice.go: ParseSliceSlice = %v, %v", got, err))
+	}
+	if _, err := ParseSliceSlice([]string{"Warn", "Error"}); !errors.Is(err, ErrInvalidSlice) {
+		panic(fmt.Sprintf("slice.go: ParseSliceSlice did not fail: %v", err))
+	}
+	if got := SliceNames(", "); got != "Debug, Info, Warn" {
+		panic(fmt.Sprintf("slice.go: SliceNames = %q", got))
+	}
+}
@@ -0,0 +1,41 @@
+// Check that values log by name with the LogValue method generated
+// with -slog, and that undefined values include their number.
+
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+)
+
+type Slog int
+
+const (
+	Debug Slog = iota - 1
+	Info
+	Warn
+	Error
+)
+
+func main() {
+	ck(Debug, "value=Debug")
+	ck(Warn, "value=Warn")
+	ck(7, "value.name=Slog(7) value.value=7")
+}
+
+func ck(s Slog, str string) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key != "value" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("", "value", s)
+	if got := strings.TrimSpace(buf.String()); got != str {
+		panic("slog.go: " + got + " != " + str)
+	}
+}
@@ -0,0 +1,55 @@
+// Check that names given by //morestringer:alias are accepted by the
+// unmarshalers, while String keeps printing the canonical name.
+
+package main
+
+import "encoding/json"
+
+type Spelling int
+
+const (
+	Color Spelling = iota
+	//morestringer:alias Grey, Greyish
+	Gray
+	//morestringer:alias Centre
+	Center
+	Meter //morestringer:alias Metre
+)
+
+func main() {
+	ck(Color, "Color")
+	ck(Gray, "Gray")
+	ck(Center, "Center")
+	ckText("Grey", Gray)
+	ckText("Greyish", Gray)
+	ckText("Centre", Center)
+	ckJSON(`"Centre"`, Center)
+	ck(Meter, "Meter")
+	ckText("Metre", Meter)
+
+	var s Spelling
+	if err := s.UnmarshalText([]byte("Colour")); err == nil {
+		panic("spelling.go: Colour accepted")
+	}
+}
+
+func ck(s Spelling, str string) {
+	if s.String() != str {
+		panic("spelling.go: " + str)
+	}
+	ckText(str, s)
+}
+
+func ckText(str string, want Spelling) {
+	var s Spelling
+	if err := s.UnmarshalText([]byte(str)); err != nil || s != want {
+		panic("spelling.go: unmarshal text " + str)
+	}
+}
+
+func ckJSON(str string, want Spelling) {
+	var s Spelling
+	if err := json.Unmarshal([]byte(str), &s); err != nil || s != want {
+		panic("spelling.go: unmarshal json " + str)
+	}
+}
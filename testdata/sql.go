@@ -0,0 +1,59 @@
+// Check that the Scan and Value methods generated with -sql
+// accept both textual and integer columns, but no undefined values.
+
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+type Sql int
+
+const (
+	Pending Sql = iota
+	Active
+	Deleted
+)
+
+var (
+	_ sql.Scanner   = (*Sql)(nil)
+	_ driver.Valuer = Sql(0)
+)
+
+func main() {
+	ck(Pending, "Pending")
+	ck(Active, "Active")
+	ck(Deleted, "Deleted")
+	ckScan(int64(2), Deleted)
+	ckScan([]byte("Active"), Active)
+
+	var s Sql
+	if err := s.Scan("Unknown"); err == nil {
+		panic("sql.go: Unknown accepted")
+	}
+	if err := s.Scan(1.5); err == nil {
+		panic("sql.go: float accepted")
+	}
+	if err := s.Scan(int64(3)); err == nil {
+		panic("sql.go: 3 accepted")
+	}
+	if _, err := Sql(3).Value(); err == nil {
+		panic("sql.go: Sql(3) stored")
+	}
+}
+
+func ck(s Sql, str string) {
+	v, err := s.Value()
+	if err != nil || v != str {
+		panic("sql.go: " + str)
+	}
+	ckScan(v, s)
+}
+
+func ckScan(src any, want Sql) {
+	var s Sql
+	if err := s.Scan(src); err != nil || s != want {
+		panic("sql.go: scan " + want.String())
+	}
+}
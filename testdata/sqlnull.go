@@ -0,0 +1,60 @@
+// Check the NullSqlnull type generated with -sqlnull, both with
+// database/sql and with encoding/json.
+
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+type Sqlnull int
+
+const (
+	Small Sqlnull = iota
+	Medium
+	Large
+)
+
+var (
+	_ sql.Scanner   = (*NullSqlnull)(nil)
+	_ driver.Valuer = NullSqlnull{}
+)
+
+func main() {
+	ck(NullSqlnull{}, nil, "null")
+	ck(NullSqlnull{Medium, true}, "Medium", `"Medium"`)
+	ck(NullSqlnull{Large, true}, "Large", `"Large"`)
+
+	var n NullSqlnull
+	if err := n.Scan(int64(0)); err != nil || n != (NullSqlnull{Small, true}) {
+		panic("sqlnull.go: scan 0")
+	}
+	if err := n.Scan("Huge"); err == nil {
+		panic("sqlnull.go: Huge accepted")
+	}
+	var m NullSqlnull
+	if err := m.Scan("Huge"); err == nil || m.Valid {
+		panic("sqlnull.go: Huge made valid")
+	}
+}
+
+func ck(n NullSqlnull, value driver.Value, str string) {
+	v, err := n.Value()
+	if err != nil || v != value {
+		panic("sqlnull.go: value " + str)
+	}
+	var u NullSqlnull
+	if err := u.Scan(v); err != nil || u != n {
+		panic("sqlnull.go: scan " + str)
+	}
+	b, err := json.Marshal(n)
+	if err != nil || string(b) != str {
+		panic("sqlnull.go: marshal " + str)
+	}
+	u = NullSqlnull{Large, true}
+	if err := json.Unmarshal(b, &u); err != nil || u != n {
+		panic("sqlnull.go: unmarshal " + str)
+	}
+}
@@ -0,0 +1,62 @@
+// Check the methods of types of string constants.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type Strconst string
+
+const (
+	Red   Strconst = "red"
+	Green Strconst = "green"
+	Blue  Strconst = "Blue" // blue
+	// Crimson is printed as Red, which is declared first.
+	Crimson Strconst = "red"
+)
+
+func main() {
+	ck(Red, "red")
+	ck(Green, "green")
+	ck(Blue, "blue")
+	ck(Crimson, "red")
+	ck(Strconst("pink"), `Strconst("pink")`)
+	for name, want := range map[string]Strconst{"red": Red, "Red": Red, "Crimson": Red, "blue": Blue, "Blue": Blue} {
+		if got, err := ParseStrconst(name); err != nil || got != want {
+			panic(fmt.Sprintf("strconst.go: ParseStrconst(%q) = %q, %v", name, got, err))
+		}
+	}
+	if _, err := ParseStrconst("gren"); !errors.Is(err, ErrInvalidStrconst) {
+		panic(fmt.Sprintf("strconst.go: ParseStrconst(\"gren\") = %v", err))
+	}
+	if got := StrconstValues(); !slices.Equal(got, []Strconst{Red, Green, Blue}) {
+		panic(fmt.Sprintf("strconst.go: StrconstValues = %q", got))
+	}
+	if !Green.IsValid() || Strconst("pink").IsValid() {
+		panic("strconst.go: IsValid")
+	}
+	var v struct{ C Strconst }
+	if err := json.Unmarshal([]byte(`{"C": "blue"}`), &v); err != nil || v.C != Blue {
+		panic(fmt.Sprintf("strconst.go: UnmarshalJSON = %q, %v", v.C, err))
+	}
+	if err := json.Unmarshal([]byte(`{"C": 1}`), &v); err == nil {
+		panic("strconst.go: UnmarshalJSON accepted a number")
+	}
+	if b, err := json.Marshal(v); err != nil || string(b) != `{"C":"blue"}` {
+		panic(fmt.Sprintf("strconst.go: MarshalJSON = %s, %v", b, err))
+	}
+	var t Strconst
+	if err := t.UnmarshalText([]byte("green")); err != nil || t != Green {
+		panic(fmt.Sprintf("strconst.go: UnmarshalText = %q, %v", t, err))
+	}
+}
+
+func ck(s Strconst, str string) {
+	if fmt.Sprint(s) != str {
+		panic("strconst.go: " + str)
+	}
+}
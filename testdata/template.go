@@ -0,0 +1,37 @@
+// Check TTemplateFuncs generated by -templatefuncs.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+type Template int
+
+const (
+	Red Template = iota
+	Green
+	Blue
+)
+
+const text = `{{range TemplateNames}}{{.}} {{end}}` +
+	`{{ParseTemplate "Blue" | printf "%d"}} ` +
+	`{{IsValidTemplate .Valid}} {{IsValidTemplate .Invalid}}`
+
+func main() {
+	tmpl := template.Must(template.New("").Funcs(TemplateTemplateFuncs()).Parse(text))
+	var b strings.Builder
+	err := tmpl.Execute(&b, struct{ Valid, Invalid Template }{Green, 5})
+	if err != nil {
+		panic(fmt.Sprintf("template.go: %v", err))
+	}
+	if got, want := b.String(), "Red Green Blue 2 true false"; got != want {
+		panic(fmt.Sprintf("template.go: got %q, want %q", got, want))
+	}
+	err = template.Must(template.New("").Funcs(TemplateTemplateFuncs()).Parse(`{{ParseTemplate "Pink"}}`)).Execute(&b, nil)
+	if err == nil {
+		panic("template.go: parsing Pink did not fail")
+	}
+}
@@ -0,0 +1,58 @@
+// Check that the MarshalText, AppendText and UnmarshalText methods
+// generated with -text round-trip through encoding/json, also as map keys.
+
+package main
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+var _ encoding.TextAppender = Text(0)
+
+type Text int
+
+const (
+	Red Text = iota
+	Green
+	Blue
+)
+
+func main() {
+	ck(Red, "Red")
+	ck(Green, "Green")
+	ck(Blue, "Blue")
+	ckMap(map[Text]int{Red: 1, Blue: 3}, `{"Blue":3,"Red":1}`)
+
+	var t Text
+	if err := t.UnmarshalText([]byte("Purple")); err == nil {
+		panic("text.go: Purple accepted")
+	}
+}
+
+func ck(t Text, str string) {
+	b, err := t.MarshalText()
+	if err != nil || string(b) != str {
+		panic("text.go: " + str)
+	}
+	var u Text
+	if err := u.UnmarshalText(b); err != nil || u != t {
+		panic("text.go: unmarshal " + str)
+	}
+	b, err = t.AppendText([]byte("color="))
+	if err != nil || string(b) != "color="+str {
+		panic("text.go: append " + str)
+	}
+}
+
+func ckMap(m map[Text]int, str string) {
+	b, err := json.Marshal(m)
+	if err != nil || string(b) != str {
+		panic(fmt.Sprintf("text.go: %s != %s", b, str))
+	}
+	var u map[Text]int
+	if err := json.Unmarshal(b, &u); err != nil || len(u) != len(m) || u[Red] != 1 || u[Blue] != 3 {
+		panic("text.go: unmarshal " + str)
+	}
+}
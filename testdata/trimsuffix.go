@@ -0,0 +1,26 @@
+// Check that -trimsuffix removes the suffix from the names.
+
+package main
+
+import "fmt"
+
+type Trimsuffix int
+
+const (
+	ActiveState Trimsuffix = iota
+	IdleState
+	Stopped
+)
+
+func main() {
+	ck(ActiveState, "Active")
+	ck(IdleState, "Idle")
+	ck(Stopped, "Stopped")
+	ck(-1, "Trimsuffix(-1)")
+}
+
+func ck(t Trimsuffix, str string) {
+	if fmt.Sprint(t) != str {
+		panic("trimsuffix.go: " + str)
+	}
+}
@@ -0,0 +1,43 @@
+// Check that -unknown=number stores numbers given as names.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Unknownnum uint8
+
+const (
+	First Unknownnum = iota + 1
+	Second
+)
+
+func main() {
+	ckText("Second", Second)
+	ckText("2", Second)
+	ckText("42", 42)
+	ckJSON(`"42"`, 42)
+	ckJSON(`"First"`, First)
+	var u Unknownnum
+	for _, text := range []string{"Third", "-1", "256"} {
+		if err := u.UnmarshalText([]byte(text)); err == nil {
+			panic(fmt.Sprintf("unknownnum.go: %s accepted", text))
+		}
+	}
+}
+
+func ckText(text string, want Unknownnum) {
+	var u Unknownnum
+	if err := u.UnmarshalText([]byte(text)); err != nil || u != want {
+		panic(fmt.Sprintf("unknownnum.go: unmarshal text %q = %v, %v", text, u, err))
+	}
+}
+
+func ckJSON(text string, want Unknownnum) {
+	var u Unknownnum
+	if err := json.Unmarshal([]byte(text), &u); err != nil || u != want {
+		panic(fmt.Sprintf("unknownnum.go: unmarshal json %s = %v, %v", text, u, err))
+	}
+}
@@ -0,0 +1,39 @@
+// Check that -unknown=zero ignores unknown names.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type Unknownzero int
+
+const (
+	Unset Unknownzero = iota
+	Set
+)
+
+func main() {
+	ckText("Set", Set)
+	ckText("Reset", Unset)
+	ckJSON(`"Reset"`, Unset)
+	var u Unknownzero
+	if err := json.Unmarshal([]byte(`true`), &u); err == nil {
+		panic("unknownzero.go: true accepted")
+	}
+}
+
+func ckText(text string, want Unknownzero) {
+	u := Set
+	if err := u.UnmarshalText([]byte(text)); err != nil || u != want {
+		panic(fmt.Sprintf("unknownzero.go: unmarshal text %q = %v, %v", text, u, err))
+	}
+}
+
+func ckJSON(text string, want Unknownzero) {
+	u := Set
+	if err := json.Unmarshal([]byte(text), &u); err != nil || u != want {
+		panic(fmt.Sprintf("unknownzero.go: unmarshal json %s = %v, %v", text, u, err))
+	}
+}
@@ -0,0 +1,39 @@
+// Check TUsage generated by -usage, wrapping long lists of names.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Usage int
+
+const (
+	January Usage = iota + 1
+	February
+	March
+	April
+	May
+	June
+	July
+	August
+	September
+	October
+	November
+	December
+	Month = May
+)
+
+func main() {
+	want := "one of: January, February, March, April, May, June, July, August,\n" +
+		"September, October, November, December"
+	if got := UsageUsage(); got != want {
+		panic(fmt.Sprintf("usage.go: UsageUsage = %q, want %q", got, want))
+	}
+	for line := range strings.Lines(UsageUsage()) {
+		if len(line) > 72 {
+			panic(fmt.Sprintf("usage.go: line %q is too long", line))
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Check the Validate method generated by -validate, together with -parse.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Validate int
+
+const (
+	Pending Validate = iota
+	Done
+)
+
+func main() {
+	if err := Done.Validate(); err != nil {
+		panic(fmt.Sprintf("validate.go: Done.Validate() = %v", err))
+	}
+	err := Validate(7).Validate()
+	var invalid *InvalidValidateError
+	if !errors.As(err, &invalid) || invalid.Value != 7 {
+		panic(fmt.Sprintf("validate.go: Validate(7).Validate() = %v", err))
+	}
+	if err.Error() != "invalid Validate: 7" {
+		panic(fmt.Sprintf("validate.go: unexpected message %q", err))
+	}
+	if !errors.Is(err, ErrInvalidValidate) {
+		panic("validate.go: error does not match ErrInvalidValidate")
+	}
+}
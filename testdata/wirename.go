@@ -0,0 +1,32 @@
+// Check WireName generated by -wirename next to display names given by
+// -linecomment, and that -text accepts both.
+
+package main
+
+import "fmt"
+
+type Wirename int
+
+const (
+	StatusActive    Wirename = iota // Active account
+	StatusOnHold                    // On hold
+	StatusHTTPError                 // Unreachable
+)
+
+func main() {
+	ck(StatusActive, "Active account", "active")
+	ck(StatusOnHold, "On hold", "on_hold")
+	ck(StatusHTTPError, "Unreachable", "http_error")
+	for _, name := range []string{"On hold", "on_hold", "StatusOnHold"} {
+		var i Wirename
+		if err := i.UnmarshalText([]byte(name)); err != nil || i != StatusOnHold {
+			panic(fmt.Sprintf("wirename.go: UnmarshalText(%q) = %v, %v", name, i, err))
+		}
+	}
+}
+
+func ck(i Wirename, display, wire string) {
+	if i.String() != display || i.WireName() != wire {
+		panic(fmt.Sprintf("wirename.go: %q has the wire name %q", i.String(), i.WireName()))
+	}
+}
@@ -0,0 +1,46 @@
+// Check that the methods generated with -xml encode values by name,
+// both as elements and as attributes.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+type Xml int
+
+const (
+	Circle Xml = iota
+	Square
+	Triangle
+)
+
+type Shape struct {
+	Kind  Xml `xml:"kind,attr"`
+	Other Xml `xml:"other"`
+}
+
+func main() {
+	ck(Shape{Circle, Triangle}, `<Shape kind="Circle"><other>Triangle</other></Shape>`)
+	ck(Shape{Square, Square}, `<Shape kind="Square"><other>Square</other></Shape>`)
+
+	var s Shape
+	if err := xml.Unmarshal([]byte(`<Shape kind="Hexagon"></Shape>`), &s); err == nil {
+		panic("xml.go: Hexagon accepted as attribute")
+	}
+	if err := xml.Unmarshal([]byte(`<Shape><other>Hexagon</other></Shape>`), &s); err == nil {
+		panic("xml.go: Hexagon accepted as element")
+	}
+}
+
+func ck(s Shape, str string) {
+	b, err := xml.Marshal(s)
+	if err != nil || string(b) != str {
+		panic(fmt.Sprintf("xml.go: %s != %s", b, str))
+	}
+	var u Shape
+	if err := xml.Unmarshal(b, &u); err != nil || u != s {
+		panic("xml.go: unmarshal " + str)
+	}
+}
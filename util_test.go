@@ -8,6 +8,13 @@ package main
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -54,7 +61,7 @@ Outer:
 	for n, test := range splitTests {
 		values := make([]Value, len(test.input))
 		for i, v := range test.input {
-			values[i] = Value{"", "", v, test.signed, fmt.Sprint(v)}
+			values[i] = Value{value: v, signed: test.signed, str: fmt.Sprint(v)}
 		}
 		runs := splitIntoRuns(values)
 		if len(runs) != len(test.output) {
@@ -75,3 +82,537 @@ Outer:
 		}
 	}
 }
+
+var flagsTests = []struct {
+	input u
+	flags bool
+}{
+	{u{1, 2, 4}, true},
+	{u{0, 1, 2, 4, 8}, true},
+	{u{1, 2, 3, 4, 7}, true}, // Combinations.
+	{u{1, 2}, false},         // Too few bits.
+	{u{0, 1, 2, 3, 4, 5, 6, 7}, false},
+	{u{1, 2, 4, 9}, false}, // 9 is not made of flags.
+	{u{1, 2, 4, m_1}, false},
+}
+
+func TestLooksLikeFlags(t *testing.T) {
+	for _, test := range flagsTests {
+		values := make([]Value, len(test.input))
+		for i, v := range test.input {
+			values[i] = Value{value: v, str: fmt.Sprint(v)}
+		}
+		if flags := looksLikeFlags(splitIntoRuns(values)); flags != test.flags {
+			t.Errorf("looksLikeFlags(%v) = %t; expected %t", test.input, flags, test.flags)
+		}
+	}
+}
+
+var vsizeTests = []struct {
+	lo, hi uint64
+	signed bool
+	bits   int
+}{
+	{0, 255, false, 8},
+	{0, 256, false, 16},
+	{m_1, 127, true, 8},
+	{m_1, 128, true, 16},
+	{uint64(1 << 16), 1 << 32, false, 64},
+	{^uint64(1 << 31), 0, true, 64},
+	{m_2, 1<<31 - 1, true, 32},
+}
+
+func TestVsize(t *testing.T) {
+	for n, test := range vsizeTests {
+		lo := Value{value: test.lo, signed: test.signed}
+		hi := Value{value: test.hi, signed: test.signed}
+		if got := vsize(lo, hi); got != test.bits {
+			t.Errorf("#%d: vsize(%d, %d) = %d; expected %d", n, test.lo, test.hi, got, test.bits)
+		}
+	}
+}
+
+var casingTests = []struct {
+	input, screamingSnake, snake, kebab string
+	acronyms                            []string
+}{
+	{"Active", "ACTIVE", "active", "active", nil},
+	{"NotFound", "NOT_FOUND", "not_found", "not-found", nil},
+	{"HTTPStatusOK", "HTTP_STATUS_OK", "http_status_ok", "http-status-ok", nil},
+	{"not found", "NOT_FOUND", "not_found", "not-found", nil},
+	{"key_tab", "KEY_TAB", "key_tab", "key-tab", nil},
+	{"Key1", "KEY1", "key1", "key1", nil},
+	{"KEY_MINUS", "KEY_MINUS", "key_minus", "key-minus", nil},
+	{"HTTPSURL", "HTTPSURL", "httpsurl", "httpsurl", nil},
+	{"HTTPSURL", "HTTPS_URL", "https_url", "https-url", []string{"HTTP", "HTTPS", "URL"}},
+	{"HTTPStatusNotFound", "HTTP_STATUS_NOT_FOUND", "http_status_not_found", "http-status-not-found", []string{"HTTP"}},
+	{"IPv4Addr", "IPV4_ADDR", "ipv4_addr", "ipv4-addr", []string{"IPv4"}},
+	{"UserIDValid", "USER_ID_VALID", "user_id_valid", "user-id-valid", []string{"ID"}},
+	{"Identity", "IDENTITY", "identity", "identity", []string{"ID", "I"}},
+	{"HTTP2Server", "HTTP2_SERVER", "http2_server", "http2-server", []string{"HTTP"}},
+}
+
+func TestCasing(t *testing.T) {
+	for _, test := range casingTests {
+		if got := screamingSnakeCase(test.input, test.acronyms); got != test.screamingSnake {
+			t.Errorf("screamingSnakeCase(%q) = %q; expected %q", test.input, got, test.screamingSnake)
+		}
+		if got := snakeCase(test.input, test.acronyms); got != test.snake {
+			t.Errorf("snakeCase(%q) = %q; expected %q", test.input, got, test.snake)
+		}
+		if got := kebabCase(test.input, test.acronyms); got != test.kebab {
+			t.Errorf("kebabCase(%q) = %q; expected %q", test.input, got, test.kebab)
+		}
+	}
+}
+
+func TestProtoEnum(t *testing.T) {
+	pkg := memPackage("package test\nimport \"time\"\nvar _ time.Month\n", naming{})
+	path, consts, err := pkg.protoEnum("time.Month")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "time" {
+		t.Errorf("path = %q; expected %q", path, "time")
+	}
+	if len(consts) != 12 || consts[1] != "January" || consts[12] != "December" {
+		t.Errorf("consts = %v; expected January through December", consts)
+	}
+	if _, _, err := pkg.protoEnum("pb.Month"); err == nil {
+		t.Error("enum of a package which is not imported was resolved")
+	}
+}
+
+var canonicalTests = []struct {
+	input, canonical string
+}{
+	{"NotFound", "NOTFOUND"},
+	{"not found", "NOT FOUND"},
+	{"Ärger", "ÄRGER"},
+	{"ärger", "ÄRGER"},
+	{"ǆ", "Ǆ"},
+	{"\u212aelvin", "KELVIN"}, // Kelvin sign
+}
+
+var ignoreSepTests = []struct {
+	input, canonical string
+}{
+	{"NotFound", "NOTFOUND"},
+	{"not_found", "NOTFOUND"},
+	{"not-found", "NOTFOUND"},
+	{"not found", "NOTFOUND"},
+	{"Ärger_", "ÄRGER"},
+}
+
+func TestCanonical(t *testing.T) {
+	g := Generator{fold: true}
+	for _, test := range canonicalTests {
+		if got := g.canonical(test.input); got != test.canonical {
+			t.Errorf("canonical(%q) = %q; expected %q", test.input, got, test.canonical)
+		}
+	}
+	g = Generator{ignoreSep: true}
+	for _, test := range ignoreSepTests {
+		if got := g.canonical(test.input); got != test.canonical {
+			t.Errorf("canonical(%q) with -ignoresep = %q; expected %q", test.input, got, test.canonical)
+		}
+	}
+}
+
+var strategyTests = []struct {
+	gen  Generator
+	n    int
+	want string
+}{
+	{Generator{}, 10, "hash"},
+	{Generator{}, 500, "hash"},
+	{Generator{}, 501, "binary"},
+	{Generator{}, 5001, "map"},
+	{Generator{lookupStrategy: "auto", hashMax: 5, binaryMax: 10}, 6, "binary"},
+	{Generator{lookupStrategy: "auto", hashMax: 5, binaryMax: 10}, 11, "map"},
+	{Generator{lookupStrategy: "auto", hashMax: 0, binaryMax: 10}, 1, "binary"},
+	{Generator{lookupStrategy: "auto", hashMax: 0, binaryMax: 0}, 1, "map"},
+	{Generator{lookupStrategy: "map"}, 2, "map"},
+	{Generator{lookupStrategy: "hash"}, 10000, "hash"},
+}
+
+func TestStrategy(t *testing.T) {
+	for n, test := range strategyTests {
+		if got := test.gen.strategy(test.n); got != test.want {
+			t.Errorf("#%d: strategy(%d) = %q; expected %q", n, test.n, got, test.want)
+		}
+	}
+}
+
+func TestPerfectHash(t *testing.T) {
+	for _, n := range []int{1, 2, 10, 100, 3000} {
+		names := make([]string, n)
+		for i := range names {
+			names[i] = fmt.Sprintf("Name%d", i)
+		}
+		seeds, slots, ok := perfectHash(names)
+		if !ok {
+			t.Fatalf("perfectHash of %d names failed", n)
+		}
+		seen := make([]bool, n)
+		for i, name := range names {
+			seed := seeds[perfectHash32(0, name)%uint32(n)]
+			slot := int(perfectHash32(seed, name) % uint32(n))
+			if slot != slots[i] {
+				t.Errorf("%s: slot %d; expected %d", name, slot, slots[i])
+			}
+			if seen[slot] {
+				t.Errorf("%s: slot %d used twice", name, slot)
+			}
+			seen[slot] = true
+		}
+	}
+	if _, _, ok := perfectHash([]string{"Same", "Same"}); ok {
+		t.Errorf("perfectHash of duplicate names succeeded")
+	}
+}
+
+var lookupSigTests = []struct {
+	input string
+	sig   lookupSig
+	plain bool
+}{
+	{"{}ByName", lookupSig{name: "{}ByName", param: "string", result: "bool"}, true},
+	{"Parse{}(string) bool", lookupSig{name: "Parse{}", param: "string", result: "bool"}, true},
+	{"{}FromBytes([]byte)", lookupSig{name: "{}FromBytes", param: "[]byte", result: "bool"}, false},
+	{"Lookup{} error", lookupSig{name: "Lookup{}", param: "string", result: "error"}, false},
+	{"*Registry.{}ByName([]byte) error", lookupSig{recv: "*Registry", name: "{}ByName", param: "[]byte", result: "error"}, false},
+}
+
+func TestParseLookupSig(t *testing.T) {
+	for _, test := range lookupSigTests {
+		sig, err := parseLookupSig(test.input)
+		if err != nil {
+			t.Errorf("parseLookupSig(%q): %s", test.input, err)
+			continue
+		}
+		if sig != test.sig || sig.plain() != test.plain {
+			t.Errorf("parseLookupSig(%q) = %+v, plain %t; expected %+v, plain %t", test.input, sig, sig.plain(), test.sig, test.plain)
+		}
+	}
+	for _, input := range []string{"", "{}ByName(int)", "{}ByName string", "a.b.{}"} {
+		if _, err := parseLookupSig(input); err == nil {
+			t.Errorf("parseLookupSig(%q) succeeded", input)
+		}
+	}
+}
+
+var deprecationTests = []struct {
+	doc        string
+	deprecated bool
+	message    string
+}{
+	{"", false, ""},
+	{"// Red is red.", false, ""},
+	{"// Deprecated: Use Blue.", true, "Use Blue."},
+	{"// Red is red.\n//\n// Deprecated: Use Blue,\n// as red is\n// too bright.", true, "Use Blue, as red is too bright."},
+	{"// Red is not Deprecated: it is red.", false, ""},
+	{"/* Deprecated: */", true, ""},
+}
+
+func TestDeprecation(t *testing.T) {
+	for _, test := range deprecationTests {
+		f, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+test.doc+"\nconst X = 1", parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deprecated, message := deprecation(f.Decls[0].(*ast.GenDecl).Doc)
+		if deprecated != test.deprecated || message != test.message {
+			t.Errorf("deprecation(%q) = %t, %q; expected %t, %q", test.doc, deprecated, message, test.deprecated, test.message)
+		}
+	}
+}
+
+var rangeTests = []struct {
+	arg string
+	r   valueRange
+}{
+	{"ClientError 400..499", valueRange{"ClientError", "400", "499"}},
+	{"Success\tOK..NoContent", valueRange{"Success", "OK", "NoContent"}},
+	{"Negative -10..-1", valueRange{"Negative", "-10", "-1"}},
+}
+
+func TestParseRange(t *testing.T) {
+	for _, test := range rangeTests {
+		r, err := parseRange(test.arg)
+		if err != nil || r != test.r {
+			t.Errorf("parseRange(%q) = %+v, %v; expected %+v", test.arg, r, err, test.r)
+		}
+	}
+	for _, arg := range []string{"", "ClientError", "ClientError 400", "ClientError 400..", "4xx 400..499", "A 1..2 3..4"} {
+		if _, err := parseRange(arg); err == nil {
+			t.Errorf("parseRange(%q) succeeded", arg)
+		}
+	}
+}
+
+var compatTests = []struct {
+	values []lockedValue
+	ok     bool
+}{
+	{[]lockedValue{{"Low", "0"}, {"High", "1"}}, true},
+	{[]lockedValue{{"Low", "0"}, {"Medium", "1"}, {"High", "2"}}, false}, // Renumbered.
+	{[]lockedValue{{"Low", "0"}, {"High", "1"}, {"Extreme", "2"}}, true}, // Added.
+	{[]lockedValue{{"Low", "0"}, {"High", "1"}, {"Up", "1"}}, true},      // Alias added.
+	{[]lockedValue{{"Low", "0"}}, false},                                 // Removed.
+}
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), lockName)
+	lock := map[string][]lockedValue{
+		"Level": {{"Low", "0"}, {"High", "1"}},
+		"Op":    {{"a b", "-1"}, {`"quoted"`, "18446744073709551615"}},
+	}
+	if err := writeLock(path, lock); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readLock(path)
+	if err != nil || !reflect.DeepEqual(got, lock) {
+		t.Fatalf("readLock = %v, %v; expected %v", got, err, lock)
+	}
+	for _, test := range compatTests {
+		err := checkCompat("Level", lock["Level"], test.values)
+		if (err == nil) != test.ok {
+			t.Errorf("checkCompat(%v) = %v; expected ok %t", test.values, err, test.ok)
+		}
+	}
+}
+
+func TestReadCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"de.json":    `{"Level": {"Low": "Niedrig", "High": "Hoch"}}`,
+		"pt-BR.json": `{"Level": {"Low": "Baixo"}, "Op": {}}`,
+		"README":     "not a catalog",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := readCatalogs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]catalog{
+		"de":    {"Level": {"Low": "Niedrig", "High": "Hoch"}},
+		"pt-BR": {"Level": {"Low": "Baixo"}, "Op": {}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readCatalogs = %v; expected %v", got, want)
+	}
+	if _, err := readCatalogs(t.TempDir()); err == nil {
+		t.Error("readCatalogs succeeded without catalogs")
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	got, err := parseLabels(`en="Active"  de="Aktiv" pt-BR="Ativo \"A\""`)
+	want := map[string]string{"en": "Active", "de": "Aktiv", "pt-BR": `Ativo "A"`}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLabels = %q, %v; expected %q", got, err, want)
+	}
+	for _, arg := range []string{`en=Active`, `="Active"`, `en="Active" en="Aktiv"`, `en "Active"`, `en="Active`} {
+		if _, err := parseLabels(arg); err == nil {
+			t.Errorf("parseLabels(%q) succeeded", arg)
+		}
+	}
+}
+
+func TestParseTypes(t *testing.T) {
+	base := naming{trimPrefix: "X", lineComment: true}
+	types, typeNaming, err := parseTypes("Status:trimprefix=Status|St,Level:trimprefix=Lvl:addsuffix=_level,Kind", base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Status", "Level", "Kind"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("types = %q; expected %q", types, want)
+	}
+	want := map[string]naming{
+		"Status": {trimPrefix: "Status,St", lineComment: true},
+		"Level":  {trimPrefix: "Lvl", addSuffix: "_level", lineComment: true},
+	}
+	if !reflect.DeepEqual(typeNaming, want) {
+		t.Errorf("typeNaming = %+v; expected %+v", typeNaming, want)
+	}
+	pkg := memPackage("package test\ntype Status int\nconst StatusOK Status = 0\ntype Level int\nconst LvlLow Level = 0\n", naming{})
+	pkg.typeNaming = typeNaming
+	values := pkg.findValues("Status", "Level")
+	if repr := values["Status"][0].repr; repr != "OK" {
+		t.Errorf("StatusOK prints as %q; expected \"OK\"", repr)
+	}
+	if repr := values["Level"][0].repr; repr != "Low_level" {
+		t.Errorf("LvlLow prints as %q; expected \"Low_level\"", repr)
+	}
+	for _, arg := range []string{"Status:trimprefix", "Status:linecomment=true"} {
+		if _, _, err := parseTypes(arg, base); err == nil {
+			t.Errorf("parseTypes(%q) succeeded", arg)
+		}
+	}
+}
+
+const typeAliasSrc = `package test
+
+import "time"
+
+type Level int
+
+type (
+	Lvl   = Level
+	Lvl2  = Lvl
+	Month = time.Month
+)
+
+const (
+	Low Lvl = iota
+	Medium
+	High Lvl2 = 5
+	Max       = Level(7)
+	Top       = Max
+	untyped   = 8
+)
+
+const January Month = 1
+`
+
+func TestTypeAlias(t *testing.T) {
+	pkg := memPackage(typeAliasSrc, naming{})
+	values := pkg.findValues("Level", "Lvl", "Month")
+	var names []string
+	for _, v := range values["Level"] {
+		names = append(names, v.original)
+	}
+	if want := []string{"Low", "Medium", "High", "Max", "Top"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("values of Level = %q; expected %q", names, want)
+	}
+	if len(values["Lvl"]) > 0 || len(values["Month"]) > 0 {
+		t.Errorf("aliases have values %v", values)
+	}
+}
+
+var nameTemplateTests = []struct {
+	text string
+	repr string
+}{
+	{`{{.Name | trimPrefix "Status" | snake}}`, "not_found"},
+	{`{{.Trimmed | screaming}}`, "NOT_FOUND"},
+	{`{{.Type | lower}}.{{.Value}}`, "status.404"},
+	{`{{.Comment}}`, "Not found"},
+}
+
+func TestNameTemplate(t *testing.T) {
+	const src = "package test\ntype Status int\nconst StatusNotFound Status = 404 // Not found\n"
+	for _, test := range nameTemplateTests {
+		tmpl, err := parseNameTemplate(test.text, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg := memPackage(src, naming{trimPrefix: "Status", nameTemplate: tmpl})
+		if repr := pkg.findValues("Status")["Status"][0].repr; repr != test.repr {
+			t.Errorf("%s gives %q; expected %q", test.text, repr, test.repr)
+		}
+	}
+	if _, err := parseNameTemplate("{{.Name | camel}}", nil); err == nil {
+		t.Error("unknown function camel was accepted")
+	}
+}
+
+// generateErr generates the type declared by src with g and returns the
+// error stopping the generation, if any.
+func generateErr(g Generator, src, typeName string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(failure).err
+		}
+	}()
+	pkg := memPackage(src, naming{})
+	g.ranges = pkg.findRanges(typeName)
+	g.genType(typeName, pkg.findValues(typeName)[typeName])
+	return nil
+}
+
+const predicateSrc = `package test
+
+//morestringer:range Running Started..Valid
+type State int
+
+const (
+	Started State = iota
+	Valid
+	Stopped
+)
+`
+
+var predicateClashTests = []struct {
+	name string
+	gen  Generator
+	err  string
+}{
+	{"predicates", Generator{predicates: true}, ""},
+	{"isvalid", Generator{predicates: true, isValid: true}, "State: the predicate IsValid of constant Valid is also generated by -isvalid"},
+	{"range", Generator{isValid: true}, ""},
+}
+
+func TestPredicateClash(t *testing.T) {
+	for _, test := range predicateClashTests {
+		err := generateErr(test.gen, predicateSrc, "State")
+		if got := fmt.Sprint(err); err == nil && test.err != "" || err != nil && got != test.err {
+			t.Errorf("%s: got error %v; expected %q", test.name, err, test.err)
+		}
+	}
+	src := strings.Replace(predicateSrc, "range Running", "range Stopped", 1)
+	err := generateErr(Generator{predicates: true}, src, "State")
+	if want := "State: the predicate IsStopped of range Stopped is also generated by -predicates for constant Stopped"; fmt.Sprint(err) != want {
+		t.Errorf("range clashing with a predicate: got error %v; expected %q", err, want)
+	}
+}
+
+var importTests = []struct {
+	name string
+	gen  Generator
+}{
+	{"fold", Generator{fold: true}},
+	{"fold lookup", Generator{fold: true, lookup: "{}ByName"}},
+	{"ignoresep", Generator{ignoreSep: true}},
+	{"ignoresep parse", Generator{ignoreSep: true, parse: "error"}},
+}
+
+// TestImports verifies that every package imported by the prologue is used
+// by the generated code, which does not compile otherwise.
+func TestImports(t *testing.T) {
+	for _, test := range importTests {
+		pkg := memPackage("package test\n"+level_in, naming{})
+		g := test.gen
+		g.intTypes = true
+		g.prologue("test")
+		g.genType("Level", pkg.findValues("Level")["Level"])
+		file, err := parser.ParseFile(token.NewFileSet(), "", g.format(), 0)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		used := make(map[string]bool)
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok {
+					used[x.Name] = true
+				}
+			}
+			return true
+		})
+		for _, imp := range file.Imports {
+			path := strings.Trim(imp.Path.Value, `"`)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if !used[name] {
+				t.Errorf("%s: %s is imported but not used", test.name, path)
+			}
+		}
+	}
+}